@@ -0,0 +1,263 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+
+	"github.com/google/uuid"
+)
+
+// maxResolvedAlerts caps the resolved alerts kept per tenant for the alerts endpoint
+const maxResolvedAlerts = 100
+
+// Detector compares each processor's short-window approval rate against its longer baseline
+// and raises an alert when the drop is statistically significant
+//
+// Significance is a two-proportion z-score between the short window and the baseline window
+// that precedes it. An alert resolves once the z-score recovers above half the threshold; it is
+// kept active while the short window has too little traffic to tell, e.g. because the circuit
+// breaker stopped routing to the processor
+type Detector struct {
+	store    *storage.InMemoryStore
+	config   *config.AnomalyConfig
+	sinks    []Sink
+	clock    clock.Clock
+	active   map[string]*models.Alert  // key: "tenant:processor:country"
+	resolved map[string][]models.Alert // key: tenant, newest first
+	mu       sync.RWMutex
+}
+
+// NewDetector creates a detector reading transactions from store and emitting alerts to sinks
+func NewDetector(store *storage.InMemoryStore, cfg *config.AnomalyConfig, sinks ...Sink) *Detector {
+	return &Detector{
+		store:    store,
+		config:   cfg,
+		sinks:    sinks,
+		clock:    store.Clock(),
+		active:   make(map[string]*models.Alert),
+		resolved: make(map[string][]models.Alert),
+	}
+}
+
+// Run evaluates the processors of every tenant every configured interval until ctx is cancelled
+func (d *Detector) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate checks every processor of every tenant once and returns the alerts raised, escalated or resolved by this pass
+func (d *Detector) Evaluate(ctx context.Context) []models.Alert {
+	now := d.clock.Now()
+
+	changed := make([]models.Alert, 0)
+	for _, tenant := range config.Tenants() {
+		processors := config.ProcessorsByTenant[tenant]
+		countries := make([]string, 0, len(processors))
+		for country := range processors {
+			countries = append(countries, country)
+		}
+		sort.Strings(countries)
+
+		for _, country := range countries {
+			for _, processor := range processors[country] {
+				if alert := d.evaluate(tenant, processor, country, now); alert != nil {
+					changed = append(changed, *alert)
+				}
+			}
+		}
+	}
+
+	for _, alert := range changed {
+		d.emit(ctx, alert)
+	}
+
+	return changed
+}
+
+// ActiveAlerts returns the alerts of every tenant that are currently active
+func (d *Detector) ActiveAlerts() []models.Alert {
+	return d.Alerts("", models.AlertStatusActive)
+}
+
+// Alerts returns a tenant's alerts (every tenant's when tenant is empty) with the given status,
+// or all known alerts when status is empty
+// Active alerts come first, ordered by start time; resolved alerts follow, newest first
+func (d *Detector) Alerts(tenant, status string) []models.Alert {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	alerts := make([]models.Alert, 0)
+	if status == "" || status == models.AlertStatusActive {
+		for _, alert := range d.active {
+			if tenant == "" || alert.Tenant == tenant {
+				alerts = append(alerts, *alert)
+			}
+		}
+		sort.Slice(alerts, func(i, j int) bool {
+			return alerts[i].StartedAt.Before(alerts[j].StartedAt)
+		})
+	}
+	if status == "" || status == models.AlertStatusResolved {
+		resolved := make([]models.Alert, 0)
+		for alertTenant, tenantAlerts := range d.resolved {
+			if tenant == "" || alertTenant == tenant {
+				resolved = append(resolved, tenantAlerts...)
+			}
+		}
+		sort.SliceStable(resolved, func(i, j int) bool {
+			if !resolved[i].ResolvedAt.Equal(*resolved[j].ResolvedAt) {
+				return resolved[i].ResolvedAt.After(*resolved[j].ResolvedAt)
+			}
+			return resolved[i].Tenant < resolved[j].Tenant
+		})
+		alerts = append(alerts, resolved...)
+	}
+
+	return alerts
+}
+
+// evaluate updates the alert state of one processor and returns the alert if it changed in a way sinks should hear about
+func (d *Detector) evaluate(tenant, processor, country string, now time.Time) *models.Alert {
+	shortStart := now.Add(-d.config.ShortWindow)
+	shortApproved, shortTotal := d.count(tenant, processor, country, shortStart.Add(time.Nanosecond), now)
+	baselineApproved, baselineTotal := d.count(tenant, processor, country, shortStart.Add(-d.config.BaselineWindow), shortStart)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := tenant + ":" + processor + ":" + country
+	alert, isActive := d.active[key]
+
+	// Not enough traffic to judge; an active alert stays open until there is evidence of recovery
+	if shortTotal < d.config.MinSamples || baselineTotal < d.config.MinSamples {
+		if isActive {
+			alert.LastEvaluatedAt = now
+		}
+		return nil
+	}
+
+	shortRate := float64(shortApproved) / float64(shortTotal)
+	baselineRate := float64(baselineApproved) / float64(baselineTotal)
+	z := zScore(shortApproved, shortTotal, baselineApproved, baselineTotal)
+	drop := (baselineRate - shortRate) * 100.0
+
+	degraded := z <= -d.config.ZThreshold && drop >= d.config.MinDrop
+	recovered := z > -d.config.ZThreshold/2 || drop < d.config.MinDrop/2
+
+	severity := models.AlertSeverityWarning
+	if z <= -2*d.config.ZThreshold {
+		severity = models.AlertSeverityCritical
+	}
+
+	switch {
+	case !isActive && degraded:
+		alert = &models.Alert{
+			ID:        uuid.New().String(),
+			Type:      models.AlertApprovalRateDrop,
+			Severity:  severity,
+			Status:    models.AlertStatusActive,
+			Tenant:    tenant,
+			Processor: processor,
+			Country:   country,
+			StartedAt: now,
+		}
+		d.update(alert, shortRate, baselineRate, z, shortTotal, baselineTotal, now)
+		d.active[key] = alert
+		copied := *alert
+		return &copied
+
+	case isActive && recovered:
+		d.update(alert, shortRate, baselineRate, z, shortTotal, baselineTotal, now)
+		alert.Status = models.AlertStatusResolved
+		alert.ResolvedAt = &now
+		alert.Message = fmt.Sprintf("[%s] %s in %s recovered: approval rate %.1f%% against a baseline of %.1f%%", tenant, processor, country, shortRate*100.0, baselineRate*100.0)
+		delete(d.active, key)
+		resolved := append([]models.Alert{*alert}, d.resolved[tenant]...)
+		if len(resolved) > maxResolvedAlerts {
+			resolved = resolved[:maxResolvedAlerts]
+		}
+		d.resolved[tenant] = resolved
+		copied := *alert
+		return &copied
+
+	case isActive:
+		escalated := severity == models.AlertSeverityCritical && alert.Severity != models.AlertSeverityCritical
+		if escalated {
+			alert.Severity = severity
+		}
+		d.update(alert, shortRate, baselineRate, z, shortTotal, baselineTotal, now)
+		if escalated {
+			copied := *alert
+			return &copied
+		}
+	}
+
+	return nil
+}
+
+// update refreshes the measurements of an alert
+func (d *Detector) update(alert *models.Alert, shortRate, baselineRate, z float64, shortTotal, baselineTotal int, now time.Time) {
+	alert.ShortRate = shortRate * 100.0
+	alert.BaselineRate = baselineRate * 100.0
+	alert.ZScore = z
+	alert.ShortSamples = shortTotal
+	alert.BaselineSamples = baselineTotal
+	alert.LastEvaluatedAt = now
+	alert.Message = fmt.Sprintf("[%s] %s in %s approval rate dropped to %.1f%% over the last %s against a baseline of %.1f%% (z=%.2f)",
+		alert.Tenant, alert.Processor, alert.Country, alert.ShortRate, d.config.ShortWindow, alert.BaselineRate, z)
+}
+
+// count returns the approved and total transactions of a tenant's processor stamped within [from, to]
+func (d *Detector) count(tenant, processor, country string, from, to time.Time) (int, int) {
+	transactions := d.store.GetTransactionsByRange(tenant, processor, country, from, to)
+
+	approved := 0
+	for _, tx := range transactions {
+		if tx.IsApproved() {
+			approved++
+		}
+	}
+
+	return approved, len(transactions)
+}
+
+// emit sends an alert to every sink, logging sinks that fail
+func (d *Detector) emit(ctx context.Context, alert models.Alert) {
+	for _, sink := range d.sinks {
+		if err := sink.Send(ctx, alert); err != nil {
+			slog.Warn("failed to emit alert", "alert_id", alert.ID, "sink", fmt.Sprintf("%T", sink), "error", err.Error())
+		}
+	}
+}
+
+// zScore returns the two-proportion z-score of the first sample against the second
+func zScore(approved1, total1, approved2, total2 int) float64 {
+	p1 := float64(approved1) / float64(total1)
+	p2 := float64(approved2) / float64(total2)
+	pooled := float64(approved1+approved2) / float64(total1+total2)
+
+	standardError := math.Sqrt(pooled * (1 - pooled) * (1/float64(total1) + 1/float64(total2)))
+	if standardError == 0 {
+		return 0
+	}
+
+	return (p1 - p2) / standardError
+}
@@ -0,0 +1,103 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+)
+
+// Sink receives alerts when they are raised, escalated or resolved
+type Sink interface {
+	Send(ctx context.Context, alert models.Alert) error
+}
+
+// SinksFromConfig returns the sinks enabled by the anomaly configuration
+func SinksFromConfig(cfg *config.AnomalyConfig) []Sink {
+	sinks := make([]Sink, 0)
+	if cfg.LogAlerts {
+		sinks = append(sinks, NewLogSink())
+	}
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, NewWebhookSink(cfg.WebhookURL))
+	}
+	return sinks
+}
+
+// LogSink writes alerts to the structured log
+type LogSink struct{}
+
+// NewLogSink creates a sink writing to the default structured logger
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+// Send logs the alert, at error level for active critical alerts and warn level for other active ones
+func (s *LogSink) Send(ctx context.Context, alert models.Alert) error {
+	level := slog.LevelInfo
+	if alert.Status == models.AlertStatusActive {
+		level = slog.LevelWarn
+		if alert.Severity == models.AlertSeverityCritical {
+			level = slog.LevelError
+		}
+	}
+
+	slog.Log(ctx, level, alert.Message,
+		"event", "alert",
+		"alert_id", alert.ID,
+		"type", alert.Type,
+		"severity", alert.Severity,
+		"status", alert.Status,
+		"tenant", alert.Tenant,
+		"processor", alert.Processor,
+		"country", alert.Country,
+		"short_rate", alert.ShortRate,
+		"baseline_rate", alert.BaselineRate,
+		"z_score", alert.ZScore,
+	)
+	return nil
+}
+
+// WebhookSink posts alerts as JSON to a URL
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting to url with a short timeout
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Send posts the alert and fails on any non-2xx response
+func (s *WebhookSink) Send(ctx context.Context, alert models.Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+	"voltarides/smart-router/config"
+)
+
+// Roles granted to API clients
+const (
+	RoleRouterClient = "router-client" // Route payments and read processor health and stats
+	RoleIngestWriter = "ingest-writer" // Report transaction outcomes
+	RoleOperator     = "operator"      // Control circuits, manage webhooks and read everything
+	RoleAdmin        = "admin"         // Everything, including loading test data
+)
+
+// validRoles lists the roles an API key or token may grant
+var validRoles = map[string]bool{
+	RoleRouterClient: true,
+	RoleIngestWriter: true,
+	RoleOperator:     true,
+	RoleAdmin:        true,
+}
+
+// Authentication methods recorded on principals
+const (
+	MethodAPIKey    = "api_key"
+	MethodJWT       = "jwt"
+	MethodAnonymous = "anonymous"
+)
+
+var (
+	// ErrMissingCredentials is returned when a request carries neither an API key nor a bearer token
+	ErrMissingCredentials = errors.New("missing API key or bearer token")
+
+	// ErrInvalidCredentials is returned for an unknown API key or a token that fails verification
+	ErrInvalidCredentials = errors.New("invalid API key or bearer token")
+
+	// ErrUnknownTenant is returned when a request acts for a tenant without configured processors
+	ErrUnknownTenant = errors.New("unknown tenant")
+
+	// ErrTenantForbidden is returned when a client other than an admin asks to act for another tenant
+	ErrTenantForbidden = errors.New("client cannot act for another tenant")
+)
+
+// Principal is an authenticated API client
+type Principal struct {
+	Client string
+	Roles  []string
+	Method string
+	Tenant string // Business line the client routes for
+}
+
+// HasRole reports whether the principal has any of the roles; admins have every role
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, granted := range p.Roles {
+		if granted == RoleAdmin {
+			return true
+		}
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// anonymous is the principal of every request when authentication is disabled
+var anonymous = &Principal{Client: "anonymous", Roles: []string{RoleAdmin}, Method: MethodAnonymous, Tenant: config.DefaultTenant}
+
+// Authenticator resolves API keys and HS256 bearer tokens to principals
+type Authenticator struct {
+	enabled   bool
+	keys      map[[sha256.Size]byte]*Principal // key: SHA-256 of the API key
+	jwtSecret []byte
+	jwtIssuer string
+	now       func() time.Time
+}
+
+// NewAuthenticator creates an authenticator from the security configuration
+// It fails on API keys without a client, key or valid role, on keys of an unknown tenant and on keys used twice
+// Tenants must be loaded into config.ProcessorsByTenant first
+func NewAuthenticator(cfg *config.SecurityConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		enabled:   cfg.AuthEnabled,
+		keys:      make(map[[sha256.Size]byte]*Principal),
+		jwtSecret: []byte(cfg.JWTSecret),
+		jwtIssuer: cfg.JWTIssuer,
+		now:       time.Now,
+	}
+
+	for _, key := range cfg.APIKeys {
+		if key.Client == "" || key.Key == "" {
+			return nil, fmt.Errorf("API key entry for client %q must be client:key:roles", key.Client)
+		}
+		if len(key.Roles) == 0 {
+			return nil, fmt.Errorf("API key for client %s has no roles", key.Client)
+		}
+		for _, role := range key.Roles {
+			if !validRoles[role] {
+				return nil, fmt.Errorf("API key for client %s has unknown role %q", key.Client, role)
+			}
+		}
+		tenant := config.TenantOrDefault(key.Tenant)
+		if !config.IsTenant(tenant) {
+			return nil, fmt.Errorf("API key for client %s has unknown tenant %q", key.Client, key.Tenant)
+		}
+
+		hash := sha256.Sum256([]byte(key.Key))
+		if _, exists := a.keys[hash]; exists {
+			return nil, fmt.Errorf("API key for client %s is already used by another client", key.Client)
+		}
+		a.keys[hash] = &Principal{Client: key.Client, Roles: key.Roles, Method: MethodAPIKey, Tenant: tenant}
+	}
+
+	return a, nil
+}
+
+// Enabled reports whether credentials are checked
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// Authenticate resolves an API key or, when no key is given, a bearer token
+// With authentication disabled every caller is an anonymous admin
+func (a *Authenticator) Authenticate(apiKey, bearerToken string) (*Principal, error) {
+	if !a.enabled {
+		return anonymous, nil
+	}
+
+	switch {
+	case apiKey != "":
+		// Keys are looked up by hash so the comparison time does not depend on the key
+		principal, ok := a.keys[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return nil, ErrInvalidCredentials
+		}
+		return principal, nil
+	case bearerToken != "":
+		if len(a.jwtSecret) == 0 {
+			return nil, ErrInvalidCredentials
+		}
+		claims, err := verifyJWT(bearerToken, a.jwtSecret, a.jwtIssuer, a.now())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+		}
+		return &Principal{Client: claims.Subject, Roles: claims.Roles, Method: MethodJWT, Tenant: config.TenantOrDefault(claims.Tenant)}, nil
+	default:
+		return nil, ErrMissingCredentials
+	}
+}
+
+// ResolveTenant returns the tenant a request of the principal acts for: the requested tenant, or the
+// principal's own when none is requested. Only admins may act for a tenant other than their own
+func ResolveTenant(principal *Principal, requested string) (string, error) {
+	tenant := principal.Tenant
+	if requested != "" && requested != tenant {
+		if !principal.HasRole(RoleAdmin) {
+			return "", ErrTenantForbidden
+		}
+		tenant = requested
+	}
+
+	if !config.IsTenant(tenant) {
+		return "", fmt.Errorf("%w %q", ErrUnknownTenant, tenant)
+	}
+	return tenant, nil
+}
+
+type principalKey struct{}
+
+type tenantKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal stored in ctx, or nil when the caller is not authenticated
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// WithTenant returns a copy of ctx carrying the tenant the request acts for
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant stored in ctx, or config.DefaultTenant when there is none
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return config.TenantOrDefault(tenant)
+}
@@ -0,0 +1,221 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+// Strategy names reported by the backtest
+const (
+	StrategyActual     = "actual"
+	StrategyRouter     = "smart_router"
+	StrategyRoundRobin = "round_robin"
+	StrategyRandom     = "random"
+)
+
+// Options configures a backtest run
+type Options struct {
+	Config        *config.RoutingConfig // Routing configuration under test (defaults to config.GetRoutingConfig)
+	WarmUp        time.Duration         // History replayed before decisions are scored
+	OutcomeWindow time.Duration         // Window centred on each decision used to estimate counterfactual approval (defaults to 10m)
+	Seed          int64                 // Seed for the random strategy
+	Logger        *slog.Logger          // Receives the decision and circuit logs of the replay (defaults to discarding them)
+}
+
+// StrategyResult holds the outcome a single strategy would have achieved
+type StrategyResult struct {
+	Strategy          string         `json:"strategy"`
+	Decisions         int            `json:"decisions"`
+	ExpectedApprovals float64        `json:"expected_approvals"`
+	ApprovalRate      float64        `json:"approval_rate"`
+	RoutingFailures   int            `json:"routing_failures"` // Decisions that fell back to the processor actually used
+	Distribution      map[string]int `json:"distribution"`
+}
+
+// Report summarises a backtest run
+type Report struct {
+	Start        time.Time        `json:"start"`
+	End          time.Time        `json:"end"`
+	Transactions int              `json:"transactions"`
+	Scored       int              `json:"scored"`
+	Strategies   []StrategyResult `json:"strategies"`
+}
+
+// Run replays transactions in timestamp order through the routing logic with a simulated clock
+//
+// At each step the clock is moved to the transaction time, every strategy picks a processor
+// for the transaction's tenant and country, and the real outcome is then added to the store so later
+// decisions see it. The actual strategy is scored with real outcomes; the others are scored with
+// the approval rate their chosen processor achieved in the dataset around that moment
+func Run(transactions []models.Transaction, opts Options) (*Report, error) {
+	if len(transactions) == 0 {
+		return nil, errors.New("no transactions to replay")
+	}
+
+	if opts.Config == nil {
+		opts.Config = config.GetRoutingConfig()
+	}
+	if opts.OutcomeWindow == 0 {
+		opts.OutcomeWindow = 10 * time.Minute
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+
+	// Replay in chronological order
+	ordered := make([]models.Transaction, len(transactions))
+	copy(ordered, transactions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp.Before(ordered[j].Timestamp)
+	})
+
+	start := ordered[0].Timestamp
+	simulatedClock := clock.NewSimulated(start)
+	store := storage.NewInMemoryStore(storage.WithClock(simulatedClock))
+	// Replayed decisions are neither counted in the live metrics nor logged like production traffic
+	service := services.NewRoutingService(store, opts.Config, services.WithoutMetrics())
+	ctx := logger.WithContext(context.Background(), opts.Logger)
+	outcomes := newOutcomeIndex(ordered)
+	random := rand.New(rand.NewSource(opts.Seed))
+	roundRobin := make(map[string]int) // key: "tenant:country"
+
+	results := map[string]*StrategyResult{}
+	strategies := []string{StrategyActual, StrategyRouter, StrategyRoundRobin, StrategyRandom}
+	for _, name := range strategies {
+		results[name] = &StrategyResult{Strategy: name, Distribution: make(map[string]int)}
+	}
+
+	scored := 0
+	for _, tx := range ordered {
+		simulatedClock.Set(tx.Timestamp)
+
+		tenant := config.TenantOrDefault(tx.Tenant)
+		processors, supported := config.ProcessorsByTenant[tenant][tx.Country]
+		if supported && len(processors) > 0 && !tx.Timestamp.Before(start.Add(opts.WarmUp)) {
+			scored++
+
+			// Processor actually used
+			actual := results[StrategyActual]
+			actual.Decisions++
+			actual.Distribution[tx.Processor]++
+			if tx.IsApproved() {
+				actual.ExpectedApprovals++
+			}
+
+			// Smart router, falling back to the processor actually used when it cannot decide
+			router := results[StrategyRouter]
+			chosen := tx.Processor
+			response, err := service.ForTenant(tenant).SelectBestProcessor(ctx, models.RoutingRequest{
+				Amount:   tx.Amount,
+				Currency: tx.Currency,
+				Country:  tx.Country,
+			}, false)
+			if err != nil {
+				router.RoutingFailures++
+			} else {
+				chosen = response.Processor
+			}
+			router.score(chosen, outcomes.estimate(tenant, chosen, tx.Country, tx.Timestamp, opts.OutcomeWindow))
+
+			// Round robin across the country's processors
+			next := processors[roundRobin[tenant+":"+tx.Country]%len(processors)]
+			roundRobin[tenant+":"+tx.Country]++
+			results[StrategyRoundRobin].score(next, outcomes.estimate(tenant, next, tx.Country, tx.Timestamp, opts.OutcomeWindow))
+
+			// Uniformly random processor
+			pick := processors[random.Intn(len(processors))]
+			results[StrategyRandom].score(pick, outcomes.estimate(tenant, pick, tx.Country, tx.Timestamp, opts.OutcomeWindow))
+		}
+
+		// The observed outcome becomes history for the next step
+		store.AddTransaction(tx)
+	}
+
+	report := &Report{
+		Start:        start,
+		End:          ordered[len(ordered)-1].Timestamp,
+		Transactions: len(ordered),
+		Scored:       scored,
+		Strategies:   make([]StrategyResult, 0, len(strategies)),
+	}
+
+	for _, name := range strategies {
+		result := results[name]
+		if result.Decisions > 0 {
+			result.ApprovalRate = (result.ExpectedApprovals / float64(result.Decisions)) * 100.0
+		}
+		report.Strategies = append(report.Strategies, *result)
+	}
+
+	return report, nil
+}
+
+// score records a decision for processor with the given approval probability
+func (r *StrategyResult) score(processor string, approvalProbability float64) {
+	r.Decisions++
+	r.Distribution[processor]++
+	r.ExpectedApprovals += approvalProbability
+}
+
+// outcomeIndex answers "how did this processor perform around time t" from the full dataset
+type outcomeIndex struct {
+	series map[string]*processorSeries // key: "tenant:processor:country"
+}
+
+// processorSeries holds a processor's transactions in time order with cumulative approvals
+type processorSeries struct {
+	timestamps []time.Time
+	approved   []int // approved[i] = approvals among the first i transactions
+}
+
+func newOutcomeIndex(ordered []models.Transaction) *outcomeIndex {
+	index := &outcomeIndex{series: make(map[string]*processorSeries)}
+	for _, tx := range ordered {
+		key := config.TenantOrDefault(tx.Tenant) + ":" + tx.Processor + ":" + tx.Country
+		series, exists := index.series[key]
+		if !exists {
+			series = &processorSeries{approved: []int{0}}
+			index.series[key] = series
+		}
+
+		approved := series.approved[len(series.approved)-1]
+		if tx.IsApproved() {
+			approved++
+		}
+		series.timestamps = append(series.timestamps, tx.Timestamp)
+		series.approved = append(series.approved, approved)
+	}
+	return index
+}
+
+// estimate returns the approval probability of a tenant's processor in a window centred on t
+// When the processor has no transactions in that window its overall rate is used instead
+func (idx *outcomeIndex) estimate(tenant, processor, country string, t time.Time, window time.Duration) float64 {
+	series, exists := idx.series[tenant+":"+processor+":"+country]
+	if !exists || len(series.timestamps) == 0 {
+		return 0.0
+	}
+
+	from := sort.Search(len(series.timestamps), func(i int) bool {
+		return !series.timestamps[i].Before(t.Add(-window / 2))
+	})
+	to := sort.Search(len(series.timestamps), func(i int) bool {
+		return series.timestamps[i].After(t.Add(window / 2))
+	})
+
+	if to <= from {
+		from, to = 0, len(series.timestamps)
+	}
+
+	return float64(series.approved[to]-series.approved[from]) / float64(to-from)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// circuitPath fills the :processor and :country parameters of a circuit route
+func circuitPath(route, processor, country string) string {
+	path := strings.Replace(route, ":processor", url.PathEscape(processor), 1)
+	return v1(strings.Replace(path, ":country", url.PathEscape(country), 1))
+}
+
+// OpenCircuit forces a processor's circuit open until the override expires
+func (c *Client) OpenCircuit(ctx context.Context, processor, country string, override models.CircuitOverrideRequest) (*models.ProcessorStats, error) {
+	return c.overrideCircuit(ctx, constants.CircuitOpen, processor, country, override)
+}
+
+// CloseCircuit forces a processor's circuit closed until the override expires
+func (c *Client) CloseCircuit(ctx context.Context, processor, country string, override models.CircuitOverrideRequest) (*models.ProcessorStats, error) {
+	return c.overrideCircuit(ctx, constants.CircuitClose, processor, country, override)
+}
+
+// PinCircuit pins a processor's circuit to override.State until the override expires
+func (c *Client) PinCircuit(ctx context.Context, processor, country string, override models.CircuitOverrideRequest) (*models.ProcessorStats, error) {
+	return c.overrideCircuit(ctx, constants.CircuitPin, processor, country, override)
+}
+
+func (c *Client) overrideCircuit(ctx context.Context, route, processor, country string, override models.CircuitOverrideRequest) (*models.ProcessorStats, error) {
+	var stats models.ProcessorStats
+	if err := c.do(ctx, http.MethodPost, circuitPath(route, processor, country), nil, override, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ReleaseCircuit removes a manual override and returns the circuit to automatic control
+func (c *Client) ReleaseCircuit(ctx context.Context, processor, country string) (*models.ProcessorStats, error) {
+	var stats models.ProcessorStats
+	if err := c.do(ctx, http.MethodDelete, circuitPath(constants.CircuitOverride, processor, country), nil, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CircuitHistory returns circuit breaker transitions, oldest first; empty filters and zero times are not sent
+func (c *Client) CircuitHistory(ctx context.Context, processor, country string, from, to time.Time) ([]models.CircuitEvent, error) {
+	query := url.Values{}
+	stringQuery(query, "processor", processor)
+	stringQuery(query, "country", country)
+	timeQuery(query, "from", from)
+	timeQuery(query, "to", to)
+
+	var response models.CircuitHistoryResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.CircuitHistory), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Events, nil
+}
@@ -0,0 +1,323 @@
+// Package client is a typed Go client for the Volta Router HTTP API
+//
+//	router := client.New("http://volta-router:8080",
+//		client.WithTimeout(500*time.Millisecond),
+//		client.WithFallbackProcessors(map[string]string{"BR": "RapidPay_BR"}),
+//	)
+//	decision, err := router.Route(ctx, models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// Version is sent in the User-Agent header
+const Version = "1.0.0"
+
+// APIError is an error response from the router
+type APIError struct {
+	StatusCode int
+	Code       string // Stable error code, e.g. "unsupported_country"; empty when the body was not a router error
+	Message    string
+	RetryAfter time.Duration // From the Retry-After header, when present
+}
+
+// Error returns the code and message
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("router returned %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("router returned %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// ErrInvalidResponse is returned when a successful response cannot be decoded
+var ErrInvalidResponse = errors.New("invalid router response")
+
+// Client calls the router's HTTP API
+//
+// Failed attempts of reads and routing decisions are retried with exponential backoff on network errors
+// and on 429, 502, 504 and 503 responses that did not come from the router itself. Routing errors such as
+// no_processor_data are returned immediately, as retrying within the backoff window would not change the
+// answer. Calls that change state, such as ingesting a transaction, are only retried after a 429, since
+// after a timeout or a gateway error the router may already have applied them
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	timeout     time.Duration
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	headers     http.Header
+
+	fallbackProcessors map[string]string // key: country
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout of each attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetryPolicy sets the attempts per call and the backoff between them
+// The wait before attempt n+1 is base * 2^(n-1), capped at max; one attempt disables retries
+func WithRetryPolicy(maxAttempts int, base, max time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseBackoff = base
+		c.maxBackoff = max
+	}
+}
+
+// WithHeader adds a header to every request
+func WithHeader(name, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(name, value)
+	}
+}
+
+// WithFallbackProcessors sets the processor to use per country when the router is unreachable
+// Route and RouteWithFailover then answer locally instead of returning the network error
+func WithFallbackProcessors(processors map[string]string) Option {
+	return func(c *Client) {
+		c.fallbackProcessors = processors
+	}
+}
+
+// New creates a client for the router at baseURL (e.g. http://localhost:8080)
+// Defaults: 2s per attempt, 3 attempts backing off from 100ms up to 1s, no fallback
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		httpClient:  &http.Client{},
+		timeout:     2 * time.Second,
+		maxAttempts: 3,
+		baseBackoff: 100 * time.Millisecond,
+		maxBackoff:  time.Second,
+		headers:     make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// v1 returns the path of a versioned API route
+func v1(path string) string {
+	return "/" + constants.MicroserviceName + constants.V1 + path
+}
+
+// do sends a request and decodes a successful JSON response into out
+// GET requests are retried; other requests change state and are only retried when rate limited
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	return c.call(ctx, method, path, query, body, out, method == http.MethodGet)
+}
+
+// doIdempotent sends a request that is safe to repeat, such as a routing decision, retrying failed attempts
+func (c *Client) doIdempotent(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	return c.call(ctx, method, path, query, body, out, true)
+}
+
+// call sends a request, retrying failed attempts that could succeed and, unless the request is idempotent,
+// were rejected without being applied
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out interface{}, idempotent bool) error {
+	var payload []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		payload = encoded
+	}
+
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = c.send(ctx, method, endpoint, payload, out)
+		if err == nil || attempt >= c.maxAttempts || !retryable(err) || (!idempotent && !rejected(err)) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single attempt
+func (c *Client) send(ctx context.Context, method, endpoint string, payload []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "volta-router-go-client/"+Version)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return nil
+}
+
+// newAPIError builds the error of a non-2xx response, keeping the router's error code when the body has one
+func newAPIError(resp *http.Response, data []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var body models.ErrorResponse
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		apiErr.Code = body.Error
+		apiErr.Message = body.Message
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return apiErr
+}
+
+// retryable reports whether another attempt could succeed
+func retryable(err error) bool {
+	if errors.Is(err, ErrInvalidResponse) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// Network error or attempt timeout
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	case http.StatusServiceUnavailable:
+		return apiErr.Code == ""
+	default:
+		return false
+	}
+}
+
+// rejected reports whether the router turned a request away without applying it
+func rejected(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// unreachable reports whether err means the router could not be reached, rather than that it refused the request
+func unreachable(err error) bool {
+	if errors.Is(err, ErrInvalidResponse) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	case http.StatusServiceUnavailable:
+		return apiErr.Code == ""
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the attempt after the given one, honouring Retry-After
+func (c *Client) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+
+	wait := c.baseBackoff << (attempt - 1)
+	if wait > c.maxBackoff || wait <= 0 {
+		wait = c.maxBackoff
+	}
+	return wait
+}
+
+// timeQuery adds an optional RFC3339 timestamp to a query
+func timeQuery(query url.Values, name string, value time.Time) {
+	if !value.IsZero() {
+		query.Set(name, value.UTC().Format(time.RFC3339))
+	}
+}
+
+// stringQuery adds an optional string to a query
+func stringQuery(query url.Values, name, value string) {
+	if value != "" {
+		query.Set(name, value)
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// processorPath fills the :name parameter of a processor route
+func processorPath(route, name string) string {
+	return v1(strings.Replace(route, ":name", url.PathEscape(name), 1))
+}
+
+// Processors returns the health of every processor
+func (c *Client) Processors(ctx context.Context) ([]models.ProcessorStats, error) {
+	var response models.ProcessorHealthResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.Processors), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Processors, nil
+}
+
+// Processor returns the health of one processor
+func (c *Client) Processor(ctx context.Context, name string) (*models.ProcessorStats, error) {
+	var stats models.ProcessorStats
+	if err := c.do(ctx, http.MethodGet, processorPath(constants.ProcessorByName, name), nil, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ProcessorTimeSeries returns a processor's approval rate per bucket between from and to
+// Zero times and bucket use the router's defaults
+func (c *Client) ProcessorTimeSeries(ctx context.Context, name string, from, to time.Time, bucket time.Duration) (*models.ProcessorTimeSeriesResponse, error) {
+	query := url.Values{}
+	timeQuery(query, "from", from)
+	timeQuery(query, "to", to)
+	if bucket > 0 {
+		query.Set("bucket", bucket.String())
+	}
+
+	var series models.ProcessorTimeSeriesResponse
+	if err := c.do(ctx, http.MethodGet, processorPath(constants.ProcessorTimeSeries, name), query, nil, &series); err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// FallbackReason is the reason of decisions made locally from the fallback processors
+const FallbackReason = "Router unreachable; using configured fallback processor"
+
+// IsFallback reports whether a decision was made locally because the router was unreachable
+func IsFallback(decision *models.RoutingResponse) bool {
+	return decision != nil && decision.Reason == FallbackReason
+}
+
+// Route returns the best processor for a payment and records the decision
+func (c *Client) Route(ctx context.Context, req models.RoutingRequest) (*models.RoutingResponse, error) {
+	return c.route(ctx, req, url.Values{}, true)
+}
+
+// RouteWithFailover returns the best processor with the second and third best as fallback options
+func (c *Client) RouteWithFailover(ctx context.Context, req models.RoutingRequest) (*models.RoutingResponse, error) {
+	return c.route(ctx, req, url.Values{"failover": {"true"}}, true)
+}
+
+// Simulate returns the decision the router would make without recording it or changing circuit breaker state
+// The result lists the circuit transitions a live request would have applied
+func (c *Client) Simulate(ctx context.Context, req models.RoutingRequest) (*models.RoutingResponse, error) {
+	return c.route(ctx, req, url.Values{"simulate": {"true"}}, false)
+}
+
+func (c *Client) route(ctx context.Context, req models.RoutingRequest, query url.Values, fallback bool) (*models.RoutingResponse, error) {
+	var decision models.RoutingResponse
+	err := c.doIdempotent(ctx, http.MethodPost, v1(constants.Route), query, req, &decision)
+	if err == nil {
+		return &decision, nil
+	}
+
+	if fallback && ctx.Err() == nil && unreachable(err) {
+		if processor, ok := c.fallbackProcessors[req.Country]; ok {
+			return &models.RoutingResponse{
+				Processor: processor,
+				Reason:    FallbackReason,
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+			}, nil
+		}
+	}
+
+	return nil, err
+}
+
+// RouteBatch routes several payments in one call; each result carries a decision or an error, in request order
+func (c *Client) RouteBatch(ctx context.Context, reqs []models.RoutingRequest, simulate bool) (*models.BatchRoutingResponse, error) {
+	query := url.Values{}
+	if simulate {
+		query.Set("simulate", "true")
+	}
+
+	var response models.BatchRoutingResponse
+	if err := c.doIdempotent(ctx, http.MethodPost, v1(constants.RouteBatch), query, reqs, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// WhatIf evaluates routing requests against a hypothetical configuration
+func (c *Client) WhatIf(ctx context.Context, req models.WhatIfRequest) (*models.WhatIfResponse, error) {
+	var response models.WhatIfResponse
+	if err := c.doIdempotent(ctx, http.MethodPost, v1(constants.RouteWhatIf), nil, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// DecisionsQuery filters and pages the routing decision audit log; zero values are not sent
+type DecisionsQuery struct {
+	Processor string
+	Country   string
+	From      time.Time
+	To        time.Time
+	Offset    int
+	Limit     int
+}
+
+// RoutingStats returns the routing distribution, risk levels and fallback usage for the decisions matching the query
+func (c *Client) RoutingStats(ctx context.Context, q models.RoutingStatsQuery) (*models.RoutingStats, error) {
+	query := url.Values{}
+	stringQuery(query, "country", q.Country)
+	timeQuery(query, "from", q.From)
+	timeQuery(query, "to", q.To)
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Bucket > 0 {
+		query.Set("bucket", q.Bucket.String())
+	}
+
+	var stats models.RoutingStats
+	if err := c.do(ctx, http.MethodGet, v1(constants.RoutingStats), query, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// RoutingDecisions returns a page of the routing decision audit log, newest first
+func (c *Client) RoutingDecisions(ctx context.Context, q DecisionsQuery) (*models.RoutingDecisionsResponse, error) {
+	query := url.Values{}
+	stringQuery(query, "processor", q.Processor)
+	stringQuery(query, "country", q.Country)
+	timeQuery(query, "from", q.From)
+	timeQuery(query, "to", q.To)
+	if q.Offset > 0 {
+		query.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	var response models.RoutingDecisionsResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.RoutingDecisions), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RoutingDecision returns one routing decision with its candidates and exclusions
+func (c *Client) RoutingDecision(ctx context.Context, id string) (*models.RoutingDecision, error) {
+	path := v1(strings.Replace(constants.RoutingDecision, ":id", url.PathEscape(id), 1))
+
+	var decision models.RoutingDecision
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &decision); err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
+
+// RoutingEffectiveness returns how routed traffic performed against the router's predictions
+// An empty country covers every country; zero times leave the period open
+func (c *Client) RoutingEffectiveness(ctx context.Context, country string, from, to time.Time) (*models.RoutingEffectivenessResponse, error) {
+	query := url.Values{}
+	stringQuery(query, "country", country)
+	timeQuery(query, "from", from)
+	timeQuery(query, "to", to)
+
+	var response models.RoutingEffectivenessResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.RoutingEffectiveness), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// Health checks that the router is up
+func (c *Client) Health(ctx context.Context) (*models.HealthResponse, error) {
+	var response models.HealthResponse
+	if err := c.do(ctx, http.MethodGet, constants.HealthCheck, nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Alerts returns degradation alerts with the given status: active, resolved or all; empty means active
+func (c *Client) Alerts(ctx context.Context, status string) ([]models.Alert, error) {
+	query := url.Values{}
+	stringQuery(query, "status", status)
+
+	var response models.AlertsResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.Alerts), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Alerts, nil
+}
+
+// ErrorCatalog returns every error code the router can return, for matching APIError.Code
+func (c *Client) ErrorCatalog(ctx context.Context) ([]models.ErrorCatalogEntry, error) {
+	var response models.ErrorCatalogResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.Errors), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Errors, nil
+}
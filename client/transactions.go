@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// RecordTransaction reports the outcome of a payment; set DecisionID to link it to the routing decision it followed
+func (c *Client) RecordTransaction(ctx context.Context, outcome models.TransactionOutcomeRequest) (*models.Transaction, error) {
+	var tx models.Transaction
+	if err := c.do(ctx, http.MethodPost, v1(constants.Transactions), nil, outcome, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// LoadTestData replaces every transaction with the router's bundled test data
+func (c *Client) LoadTestData(ctx context.Context) (*models.LoadDataResponse, error) {
+	var response models.LoadDataResponse
+	if err := c.do(ctx, http.MethodPost, v1(constants.TransactionsLoad), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CreateSnapshot writes the router's full store to a snapshot file; an empty name is generated from the time
+// An existing snapshot of that name fails with snapshot_exists unless req.Overwrite is set
+func (c *Client) CreateSnapshot(ctx context.Context, req models.SnapshotRequest) (*models.SnapshotResponse, error) {
+	var response models.SnapshotResponse
+	if err := c.do(ctx, http.MethodPost, v1(constants.AdminSnapshots), nil, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RestoreSnapshot replaces the router's full store with a snapshot file
+func (c *Client) RestoreSnapshot(ctx context.Context, name string) (*models.SnapshotResponse, error) {
+	path := v1(strings.Replace(constants.AdminSnapshotRestore, ":name", url.PathEscape(name), 1))
+
+	var response models.SnapshotResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ClearStore removes everything from the router's store, requesting and sending back a confirmation token
+func (c *Client) ClearStore(ctx context.Context) (*models.ClearStoreResponse, error) {
+	var confirmation models.ClearStoreConfirmation
+	if err := c.do(ctx, http.MethodPost, v1(constants.AdminStoreClear), nil, models.ClearStoreRequest{}, &confirmation); err != nil {
+		return nil, err
+	}
+
+	var response models.ClearStoreResponse
+	request := models.ClearStoreRequest{ConfirmationToken: confirmation.ConfirmationToken}
+	if err := c.do(ctx, http.MethodPost, v1(constants.AdminStoreClear), nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+)
+
+// idPath fills the :id parameter of a route
+func idPath(route, id string) string {
+	return v1(strings.Replace(route, ":id", url.PathEscape(id), 1))
+}
+
+// CreateWebhook subscribes a URL to router events; the returned subscription is the only place the secret appears
+func (c *Client) CreateWebhook(ctx context.Context, req models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := c.do(ctx, http.MethodPost, v1(constants.Webhooks), nil, req, &subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// Webhooks lists webhook subscriptions
+func (c *Client) Webhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var response models.WebhookSubscriptionsResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.Webhooks), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Subscriptions, nil
+}
+
+// DeleteWebhook removes a webhook subscription
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, idPath(constants.Webhook, id), nil, nil, nil)
+}
+
+// WebhookDeliveries returns the delivery log, filtered by subscription and status when not empty
+func (c *Client) WebhookDeliveries(ctx context.Context, subscriptionID, status string) ([]models.WebhookDelivery, error) {
+	query := url.Values{}
+	stringQuery(query, "subscription_id", subscriptionID)
+	stringQuery(query, "status", status)
+
+	var response models.WebhookDeliveriesResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.WebhookDeliveries), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Deliveries, nil
+}
+
+// WebhookDeadLetters returns deliveries that exhausted their retries
+func (c *Client) WebhookDeadLetters(ctx context.Context) ([]models.WebhookDelivery, error) {
+	var response models.WebhookDeliveriesResponse
+	if err := c.do(ctx, http.MethodGet, v1(constants.WebhookDeadLetters), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Deliveries, nil
+}
+
+// RetryWebhookDeadLetter queues a dead-lettered delivery for another round of attempts
+func (c *Client) RetryWebhookDeadLetter(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := c.do(ctx, http.MethodPost, idPath(constants.WebhookDeadLetter, id), nil, nil, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+	"voltarides/smart-router/backtest"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/data/generator"
+)
+
+func main() {
+	filepath := flag.String("data", "data/test_transactions.json", "transaction dataset to replay")
+	window := flag.Duration("window", 15*time.Minute, "approval rate time window")
+	threshold := flag.Float64("circuit-threshold", 60.0, "circuit breaker approval rate threshold (%)")
+	warmUp := flag.Duration("warmup", 2*time.Minute, "history replayed before decisions are scored")
+	outcomeWindow := flag.Duration("outcome-window", 10*time.Minute, "window used to estimate counterfactual approval")
+	seed := flag.Int64("seed", 1, "seed for the random strategy")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	// Transactions of other business lines are replayed against their own processors
+	if err := config.LoadTenantProcessors(); err != nil {
+		log.Fatalf("Invalid tenant configuration: %v", err)
+	}
+
+	transactions, err := generator.LoadTransactionsFromFile(*filepath)
+	if err != nil {
+		log.Fatalf("Failed to load transactions: %v", err)
+	}
+
+	cfg := config.GetRoutingConfig()
+	cfg.TimeWindow = *window
+	cfg.CircuitBreakerThreshold = *threshold
+
+	report, err := backtest.Run(transactions, backtest.Options{
+		Config:        cfg,
+		WarmUp:        *warmUp,
+		OutcomeWindow: *outcomeWindow,
+		Seed:          *seed,
+	})
+	if err != nil {
+		log.Fatalf("Backtest failed: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+		return
+	}
+
+	fmt.Printf("Replayed %d transactions from %s to %s (%d scored)\n\n",
+		report.Transactions,
+		report.Start.Format(time.RFC3339),
+		report.End.Format(time.RFC3339),
+		report.Scored,
+	)
+
+	fmt.Printf("%-14s %10s %14s %10s\n", "STRATEGY", "DECISIONS", "APPROVAL RATE", "FAILURES")
+	for _, result := range report.Strategies {
+		fmt.Printf("%-14s %10d %13.2f%% %10d\n", result.Strategy, result.Decisions, result.ApprovalRate, result.RoutingFailures)
+	}
+
+	for _, result := range report.Strategies {
+		fmt.Printf("\n%s distribution:\n", result.Strategy)
+
+		processors := make([]string, 0, len(result.Distribution))
+		for processor := range result.Distribution {
+			processors = append(processors, processor)
+		}
+		sort.Strings(processors)
+
+		for _, processor := range processors {
+			fmt.Printf("  %-16s %d\n", processor, result.Distribution[processor])
+		}
+	}
+}
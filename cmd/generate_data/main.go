@@ -5,6 +5,7 @@ import (
 	"log"
 	"math/rand"
 	"time"
+	"voltarides/smart-router/common/clock"
 	"voltarides/smart-router/data/generator"
 )
 
@@ -15,7 +16,7 @@ func main() {
 	fmt.Println("Generating test transaction data...")
 
 	// Generate 540 transactions (60 per processor * 9 processors)
-	transactions := generator.GenerateTestTransactions(540)
+	transactions := generator.GenerateTestTransactions(540, clock.New())
 
 	// Save to file
 	filepath := "data/test_transactions.json"
@@ -0,0 +1,57 @@
+package grpcServer
+
+import (
+	"log"
+	"log/slog"
+	"net"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/grpcapi"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/ratelimit"
+	"voltarides/smart-router/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// GRPCServer represents the gRPC server
+type GRPCServer struct {
+	Server *grpc.Server
+}
+
+// InitServer registers the router service backed by routingService and returns a function that serves it on port
+// Router methods require the same credentials and roles as their HTTP endpoints and share their rate limits
+func (gs *GRPCServer) InitServer(routingService *services.RoutingService, authenticator *auth.Authenticator, limiter *ratelimit.Limiter, port string) func() {
+	gs.Server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcapi.UnaryInterceptor(),
+			grpcapi.UnaryAuthInterceptor(authenticator),
+			grpcapi.UnaryRateLimitInterceptor(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			grpcapi.StreamInterceptor(),
+			grpcapi.StreamAuthInterceptor(authenticator),
+			grpcapi.StreamRateLimitInterceptor(limiter),
+		),
+	)
+
+	routerpb.RegisterRouterServiceServer(gs.Server, grpcapi.NewRouterServer(routingService))
+
+	// Standard health checks and reflection for grpcurl and load balancers
+	grpc_health_v1.RegisterHealthServer(gs.Server, health.NewServer())
+	reflection.Register(gs.Server)
+
+	return func() {
+		listener, err := net.Listen("tcp", ":"+port)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+
+		slog.Info("gRPC server listening", "port", port)
+		if err := gs.Server.Serve(listener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}
+}
@@ -1,12 +1,22 @@
 package httpServer
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"voltarides/smart-router/alerting"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/cmd/grpcServer"
 	"voltarides/smart-router/config"
 	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/ratelimit"
 	"voltarides/smart-router/routers"
 	"voltarides/smart-router/services"
 	"voltarides/smart-router/storage"
+	"voltarides/smart-router/stream"
+	"voltarides/smart-router/webhooks"
 
 	"github.com/labstack/echo/v4"
 )
@@ -31,27 +41,103 @@ func (es *EchoServer) initServer() func() {
 	routingConfig := config.GetRoutingConfig()
 	serverConfig := config.GetServerConfig()
 
-	// Initialize service
-	routingService := services.NewRoutingService(store, routingConfig)
+	// Business lines with their own processor contracts, in addition to the default tenant
+	if err := config.LoadTenantProcessors(); err != nil {
+		log.Fatalf("Invalid tenant configuration: %v", err)
+	}
+	tenantConfigs, err := config.LoadTenantRoutingConfig(routingConfig)
+	if err != nil {
+		log.Fatalf("Invalid tenant configuration: %v", err)
+	}
+
+	// Circuit and country events are pushed to webhook subscribers
+	bus := events.NewBus()
+	dispatcher := webhooks.NewDispatcher()
+	bus.Subscribe(dispatcher.Handle)
+
+	// Initialize service; requests are served by the service of the tenant they act for
+	routingService := services.NewRoutingService(store, routingConfig, services.WithEventBus(bus), services.WithTenantConfigs(tenantConfigs))
+	tenantServices := make([]*services.RoutingService, 0, len(config.ProcessorsByTenant))
+	for _, tenant := range config.Tenants() {
+		tenantServices = append(tenantServices, routingService.ForTenant(tenant))
+	}
+
+	// Export processor health gauges of every tenant
+	sources := make([]metrics.ProcessorStatsSource, 0, len(tenantServices))
+	for _, service := range tenantServices {
+		sources = append(sources, service)
+	}
+	if err := metrics.RegisterProcessorCollector(sources...); err != nil {
+		log.Fatalf("Failed to register processor metrics: %v", err)
+	}
+
+	// Watch processors for approval-rate degradation
+	anomalyConfig := config.GetAnomalyConfig()
+	detector := alerting.NewDetector(store, anomalyConfig, alerting.SinksFromConfig(anomalyConfig)...)
+	if anomalyConfig.Enabled {
+		go detector.Run(context.Background())
+	}
+
+	// Push processor health changes and router events to dashboard streams, one hub per tenant
+	streamConfig := config.GetStreamConfig()
+	hubs := make([]*stream.Hub, 0, len(tenantServices))
+	for _, service := range tenantServices {
+		hub := stream.NewHub(service, streamConfig)
+		bus.Subscribe(hub.Handle)
+		go hub.Run(context.Background())
+		hubs = append(hubs, hub)
+	}
+
+	// API keys and bearer tokens; disabled in development unless AUTH_ENABLED=true
+	securityConfig := config.GetSecurityConfig()
+	authenticator, err := auth.NewAuthenticator(securityConfig)
+	if err != nil {
+		log.Fatalf("Invalid security configuration: %v", err)
+	}
+	if securityConfig.AuthEnabled && len(securityConfig.APIKeys) == 0 && securityConfig.JWTSecret == "" {
+		slog.Warn("Authentication is enabled but no API keys or JWT secret are configured; protected endpoints will reject every request")
+	}
+
+	// Per-client and per-route request limits
+	limiter, err := ratelimit.NewLimiter(config.GetRateLimitConfig())
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %v", err)
+	}
+	if err := metrics.RegisterRateLimitBuckets(limiter.Buckets); err != nil {
+		log.Fatalf("Failed to register rate limit metrics: %v", err)
+	}
 
 	// Initialize controllers
 	routingController := controllers.NewRoutingController(routingService)
-	dataController := controllers.NewDataController(store)
+	dataController := controllers.NewDataController(store, serverConfig.Environment, config.GetSnapshotConfig())
+	circuitController := controllers.NewCircuitController(routingService)
+	alertController := controllers.NewAlertController(detector)
+	webhookController := controllers.NewWebhookController(dispatcher)
+	streamController := controllers.NewStreamController(hubs, streamConfig.Heartbeat)
+
+	// gRPC API on its own port, sharing the routing service with the HTTP API
+	rpcServer := grpcServer.GRPCServer{}
+	startGRPC := rpcServer.InitServer(routingService, authenticator, limiter, serverConfig.GRPCPort)
 
 	// Create Echo instance
 	es.Server = echo.New()
 	es.Server.HideBanner = true
 
 	// Configure routes
-	routers.ConfigRouter(es.Server, routingController, dataController)
+	routers.ConfigRouter(es.Server, authenticator, limiter, securityConfig.CORSAllowedOrigins, routingController, dataController, circuitController, alertController, webhookController, streamController)
 
-	log.Printf("🚀 Volta Router initializing...")
-	log.Printf("📍 Environment: %s", serverConfig.Environment)
-	log.Printf("🔧 Time Window: %v", routingConfig.TimeWindow)
-	log.Printf("⚠️  High Risk Threshold: %.1f%%", routingConfig.HighRiskThreshold)
-	log.Printf("🌐 Server starting on port %s", serverConfig.Port)
+	slog.Info("Volta Router initializing",
+		"environment", serverConfig.Environment,
+		"time_window", routingConfig.TimeWindow.String(),
+		"high_risk_threshold", routingConfig.HighRiskThreshold,
+		"port", serverConfig.Port,
+		"grpc_port", serverConfig.GRPCPort,
+		"auth_enabled", securityConfig.AuthEnabled,
+		"tenants", config.Tenants(),
+	)
 
 	return func() {
+		go startGRPC()
 		if err := es.Server.Start(":" + serverConfig.Port); err != nil {
 			log.Fatalf("Failed to start server: %v", err)
 		}
@@ -0,0 +1,113 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/ratelimit"
+	"voltarides/smart-router/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is a failure reported to API clients, shared by the HTTP and gRPC APIs
+// Code is stable and machine-readable; Status is the HTTP status, from which the gRPC code is derived
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// New creates an API error
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Error returns the message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Response returns the HTTP error body
+func (e *Error) Response() models.ErrorResponse {
+	return models.ErrorResponse{Error: e.Code, Message: e.Message}
+}
+
+// GRPCStatus returns the gRPC status for the error, with the code as the message prefix
+// gRPC handlers can return the error directly; the framework calls this method
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(GRPCCode(e.Status), e.Code+": "+e.Message)
+}
+
+// Validation reports a request that failed struct validation
+func Validation(err error) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, "Request validation failed: "+err.Error())
+}
+
+// Unauthorized reports a request whose credentials are missing or invalid
+func Unauthorized(err error) *Error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, err.Error())
+}
+
+// Forbidden reports an authenticated client without any of the roles an endpoint requires
+func Forbidden(client string, roles []string) *Error {
+	return New(http.StatusForbidden, CodeForbidden, fmt.Sprintf("client %s needs one of the roles: %s", client, strings.Join(roles, ", ")))
+}
+
+// Tenant maps an error from auth.ResolveTenant for a client requesting a tenant to an API error
+func Tenant(client, requested string, err error) *Error {
+	if errors.Is(err, auth.ErrTenantForbidden) {
+		return New(http.StatusForbidden, CodeForbidden, fmt.Sprintf("client %s cannot act for tenant %s", client, requested))
+	}
+	return New(http.StatusBadRequest, CodeUnknownTenant, err.Error())
+}
+
+// RateLimited reports a request rejected by rate limiting, retryable after retryAfter seconds
+func RateLimited(scope, route string, retryAfter int) *Error {
+	if scope == ratelimit.ScopeRoute {
+		return New(http.StatusTooManyRequests, CodeRateLimited, fmt.Sprintf("rate limit for %s exceeded; retry in %ds", route, retryAfter))
+	}
+	return New(http.StatusTooManyRequests, CodeRateLimited, fmt.Sprintf("request quota exceeded; retry in %ds", retryAfter))
+}
+
+// Routing maps an error from RoutingService routing to an API error
+func Routing(err error) *Error {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedCountry):
+		return New(http.StatusBadRequest, CodeUnsupportedCountry, err.Error())
+	case errors.Is(err, services.ErrInvalidCurrency):
+		return New(http.StatusUnprocessableEntity, CodeInvalidCurrency, err.Error())
+	case errors.Is(err, services.ErrAllCircuitsOpen):
+		return New(http.StatusServiceUnavailable, CodeAllCircuitsOpen, err.Error())
+	case errors.Is(err, services.ErrNoData):
+		return New(http.StatusServiceUnavailable, CodeNoProcessorData, err.Error())
+	default:
+		return New(http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+}
+
+// GRPCCode returns the gRPC code equivalent to an HTTP status
+func GRPCCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,88 @@
+package apierror
+
+import (
+	"net/http"
+	"voltarides/smart-router/models"
+)
+
+// Stable error codes returned in the "error" field of HTTP responses and as the prefix of gRPC status messages
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeInvalidQuery         = "invalid_query"
+	CodeUnsupportedCountry   = "unsupported_country"
+	CodeInvalidCurrency      = "invalid_currency"
+	CodeNoProcessorData      = "no_processor_data"
+	CodeAllCircuitsOpen      = "all_circuits_open"
+	CodeInternal             = "internal_error"
+	CodeInvalidConfig        = "invalid_config"
+	CodeProcessorNotFound    = "processor_not_found"
+	CodeDecisionNotFound     = "decision_not_found"
+	CodeInvalidOutcome       = "invalid_outcome"
+	CodeInvalidOverride      = "invalid_override"
+	CodeOverrideNotFound     = "override_not_found"
+	CodeInvalidSubscription  = "invalid_subscription"
+	CodeSubscriptionNotFound = "subscription_not_found"
+	CodeDeadLetterNotFound   = "dead_letter_not_found"
+	CodeLoadFailed           = "load_failed"
+	CodeLoadDisabled         = "load_disabled"
+	CodeInvalidSnapshot      = "invalid_snapshot"
+	CodeSnapshotNotFound     = "snapshot_not_found"
+	CodeSnapshotExists       = "snapshot_exists"
+	CodeSnapshotFailed       = "snapshot_failed"
+	CodeInvalidConfirmation  = "invalid_confirmation"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeRateLimited          = "rate_limited"
+	CodeUnknownTenant        = "unknown_tenant"
+)
+
+// catalog documents every error code, in the order they are listed by the catalog endpoint
+var catalog = []struct {
+	code        string
+	status      int
+	description string
+}{
+	{CodeInvalidRequest, http.StatusBadRequest, "The request body is not valid JSON for the endpoint."},
+	{CodeValidationFailed, http.StatusBadRequest, "A request field is missing or out of range; the message names the field."},
+	{CodeInvalidQuery, http.StatusBadRequest, "A query parameter could not be parsed or the combination is invalid."},
+	{CodeUnsupportedCountry, http.StatusBadRequest, "The router has no processors for the requested country."},
+	{CodeInvalidCurrency, http.StatusUnprocessableEntity, "The currency is not the one accepted for the requested country."},
+	{CodeNoProcessorData, http.StatusServiceUnavailable, "No processor of the country has transactions in the routing window, so no approval rate can be calculated."},
+	{CodeAllCircuitsOpen, http.StatusServiceUnavailable, "Every processor of the country is excluded by an open circuit breaker; retry after the circuit timeout."},
+	{CodeInternal, http.StatusInternalServerError, "An unexpected error occurred while routing."},
+	{CodeInvalidConfig, http.StatusBadRequest, "The what-if configuration override is invalid."},
+	{CodeProcessorNotFound, http.StatusNotFound, "No processor with that name exists (for the given country)."},
+	{CodeDecisionNotFound, http.StatusNotFound, "No routing decision with that ID exists."},
+	{CodeInvalidOutcome, http.StatusBadRequest, "The transaction outcome does not match its routing decision or names an unknown processor."},
+	{CodeInvalidOverride, http.StatusBadRequest, "The circuit override state or expiry is invalid."},
+	{CodeOverrideNotFound, http.StatusNotFound, "The circuit has no manual override to release."},
+	{CodeInvalidSubscription, http.StatusBadRequest, "The webhook subscription names an unknown event type."},
+	{CodeSubscriptionNotFound, http.StatusNotFound, "No webhook subscription with that ID exists."},
+	{CodeDeadLetterNotFound, http.StatusNotFound, "No dead-lettered webhook delivery with that ID exists."},
+	{CodeLoadFailed, http.StatusInternalServerError, "The test data file could not be loaded."},
+	{CodeLoadDisabled, http.StatusForbidden, "Loading test data replaces the store and is only allowed in the development environment."},
+	{CodeInvalidSnapshot, http.StatusBadRequest, "The snapshot name is not a plain file name, or the snapshot file is not a snapshot this version can restore."},
+	{CodeSnapshotNotFound, http.StatusNotFound, "No snapshot with that name exists in the snapshot directory."},
+	{CodeSnapshotExists, http.StatusConflict, "A snapshot with that name already exists; send overwrite to replace it."},
+	{CodeSnapshotFailed, http.StatusInternalServerError, "The snapshot file could not be written or read."},
+	{CodeInvalidConfirmation, http.StatusBadRequest, "The confirmation token for clearing the store is unknown, expired or already used; request a new one."},
+	{CodeUnauthorized, http.StatusUnauthorized, "The API key or bearer token is missing, unknown or expired."},
+	{CodeForbidden, http.StatusForbidden, "The client does not have a role allowed to call the endpoint, or asked to act for another tenant."},
+	{CodeRateLimited, http.StatusTooManyRequests, "The client exceeded its request quota or the route's rate limit; retry after the Retry-After delay."},
+	{CodeUnknownTenant, http.StatusBadRequest, "The X-Tenant header or the client's tenant names a tenant without configured processors."},
+}
+
+// Catalog returns every error code with its HTTP status, gRPC code and meaning
+func Catalog() []models.ErrorCatalogEntry {
+	entries := make([]models.ErrorCatalogEntry, 0, len(catalog))
+	for _, entry := range catalog {
+		entries = append(entries, models.ErrorCatalogEntry{
+			Code:        entry.code,
+			HTTPStatus:  entry.status,
+			GRPCCode:    GRPCCode(entry.status).String(),
+			Description: entry.description,
+		})
+	}
+	return entries
+}
@@ -9,9 +9,34 @@ const (
 
 	// Route paths
 	HealthCheck          = "/health"
+	Metrics              = "/metrics"
+	OpenAPI              = "/openapi.json"
 	Route                = "/route"
+	RouteWhatIf          = "/route/what-if"
+	RouteBatch           = "/route/batch"
 	Processors           = "/processors"
 	ProcessorByName      = "/processors/:name"
+	ProcessorStream      = "/processors/stream"
+	ProcessorTimeSeries  = "/processors/:name/timeseries"
 	RoutingStats         = "/routing/stats"
+	RoutingDecisions     = "/routing/decisions"
+	RoutingDecision      = "/routing/decisions/:id"
+	RoutingEffectiveness = "/routing/effectiveness"
+	Transactions         = "/transactions"
 	TransactionsLoad     = "/transactions/load"
+	CircuitOpen          = "/circuits/:processor/:country/open"
+	CircuitClose         = "/circuits/:processor/:country/close"
+	CircuitPin           = "/circuits/:processor/:country/pin"
+	CircuitOverride      = "/circuits/:processor/:country/override"
+	CircuitHistory       = "/circuits/history"
+	Alerts               = "/alerts"
+	Errors               = "/errors"
+	Webhooks             = "/webhooks"
+	Webhook              = "/webhooks/:id"
+	WebhookDeliveries    = "/webhooks/deliveries"
+	WebhookDeadLetters   = "/webhooks/dead-letters"
+	WebhookDeadLetter    = "/webhooks/dead-letters/:id/retry"
+	AdminSnapshots       = "/admin/snapshots"
+	AdminSnapshotRestore = "/admin/snapshots/:name/restore"
+	AdminStoreClear      = "/admin/store/clear"
 )
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"voltarides/smart-router/config"
+)
+
+// contextKey is the context key for the request-scoped logger
+type contextKey struct{}
+
+// New creates a JSON logger writing to w at the configured level
+func New(w io.Writer, cfg *config.LogConfig) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", cfg.Level)
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
+	return slog.New(handler).With(
+		"service", cfg.ServiceName,
+		"environment", cfg.Environment,
+	), nil
+}
+
+// Init creates a JSON logger on stdout and makes it the process-wide default,
+// so output from the standard log package is structured as well
+func Init(cfg *config.LogConfig) (*slog.Logger, error) {
+	logger, err := New(os.Stdout, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// WithContext returns a copy of ctx carrying logger
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger in ctx, or the default logger if there is none
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
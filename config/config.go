@@ -1,7 +1,16 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,12 +23,110 @@ type RoutingConfig struct {
 	CircuitBreakerTimeout   time.Duration
 }
 
+// Version returns a short fingerprint of the routing configuration
+// Decisions made under the same settings share a version
+func (c *RoutingConfig) Version() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%g|%g|%g|%s",
+		c.TimeWindow, c.HighRiskThreshold, c.MediumRiskThreshold, c.CircuitBreakerThreshold, c.CircuitBreakerTimeout)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Validate checks that the windows are positive and the thresholds are percentages in a consistent order
+func (c *RoutingConfig) Validate() error {
+	if c.TimeWindow <= 0 || c.CircuitBreakerTimeout <= 0 {
+		return errors.New("time_window and circuit_breaker_timeout must be positive")
+	}
+	for _, threshold := range []float64{c.HighRiskThreshold, c.MediumRiskThreshold, c.CircuitBreakerThreshold} {
+		if threshold < 0 || threshold > 100 {
+			return errors.New("thresholds must be between 0 and 100")
+		}
+	}
+	if c.MediumRiskThreshold < c.HighRiskThreshold {
+		return errors.New("medium_risk_threshold must not be below high_risk_threshold")
+	}
+	return nil
+}
+
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port        string
+	GRPCPort    string
 	Environment string
 }
 
+// TelemetryConfig holds tracing configuration
+type TelemetryConfig struct {
+	Exporter     string // "datadog", "otlp" or "none"
+	ServiceName  string
+	Environment  string
+	OTLPEndpoint string // host:port of the OTLP/HTTP collector
+	OTLPInsecure bool
+}
+
+// LogConfig holds structured logging configuration
+type LogConfig struct {
+	Level       string // "debug", "info", "warn" or "error"
+	ServiceName string
+	Environment string
+}
+
+// AnomalyConfig holds configuration for approval-rate degradation detection
+type AnomalyConfig struct {
+	Enabled        bool
+	Interval       time.Duration // How often processors are evaluated
+	ShortWindow    time.Duration // Recent window compared against the baseline
+	BaselineWindow time.Duration // Window before the short window that defines normal behaviour
+	ZThreshold     float64       // Alert when the z-score drops to -ZThreshold or below
+	MinSamples     int           // Transactions required in each window before evaluating
+	MinDrop        float64       // Percentage points the rate must drop by, so tiny but significant dips are ignored
+	LogAlerts      bool          // Emit alerts to the structured log
+	WebhookURL     string        // Emit alerts to this URL when set
+}
+
+// StreamConfig holds configuration for the live processor health stream
+type StreamConfig struct {
+	PollInterval time.Duration // How often processor stats and routing distribution are checked for changes
+	Heartbeat    time.Duration // Interval between heartbeat messages, which keep idle connections open
+}
+
+// SnapshotConfig holds configuration for the admin snapshot and reset endpoints
+type SnapshotConfig struct {
+	Dir             string        // Directory store snapshots are written to and restored from
+	ConfirmationTTL time.Duration // How long a confirmation token for clearing the store stays valid
+}
+
+// SecurityConfig holds API authentication and CORS configuration
+type SecurityConfig struct {
+	AuthEnabled        bool
+	APIKeys            []APIKeyConfig
+	JWTSecret          string   // HS256 secret; bearer tokens are only accepted when set
+	JWTIssuer          string   // Required "iss" claim when set
+	CORSAllowedOrigins []string // Empty disables CORS
+}
+
+// APIKeyConfig is an API client identified by a static key
+type APIKeyConfig struct {
+	Client string
+	Key    string
+	Roles  []string
+	Tenant string // Business line the client routes for; DefaultTenant when empty
+}
+
+// RateLimitConfig holds token-bucket rate limits
+// Callers are identified by their authenticated client name, or by IP address when anonymous
+type RateLimitConfig struct {
+	Enabled bool
+	Default RateLimit            // Quota of each caller across all routes
+	Clients map[string]RateLimit // key: client name; replaces Default for that client
+	Routes  map[string]RateLimit // key: route path under /volta-router/v1, e.g. /route; applies per caller
+}
+
+// RateLimit is a token bucket refilled at Rate tokens per second and holding at most Burst tokens
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
 // GetRoutingConfig returns the routing configuration with defaults
 func GetRoutingConfig() *RoutingConfig {
 	return &RoutingConfig{
@@ -38,6 +145,11 @@ func GetServerConfig() *ServerConfig {
 		port = "8080"
 	}
 
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+
 	environment := os.Getenv("ENVIRONMENT")
 	if environment == "" {
 		environment = "development"
@@ -45,13 +157,348 @@ func GetServerConfig() *ServerConfig {
 
 	return &ServerConfig{
 		Port:        port,
+		GRPCPort:    grpcPort,
 		Environment: environment,
 	}
 }
 
+// GetTelemetryConfig returns the tracing configuration from environment variables
+func GetTelemetryConfig(serviceName string) *TelemetryConfig {
+	exporter := os.Getenv("TELEMETRY_EXPORTER")
+	if exporter == "" {
+		exporter = "datadog"
+	}
+
+	endpoint := os.Getenv("OTLP_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "localhost:4318" // Local collector
+	}
+
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = "development"
+	}
+
+	return &TelemetryConfig{
+		Exporter:     exporter,
+		ServiceName:  serviceName,
+		Environment:  environment,
+		OTLPEndpoint: endpoint,
+		OTLPInsecure: os.Getenv("OTLP_INSECURE") != "false",
+	}
+}
+
+// GetLogConfig returns the logging configuration from environment variables
+// LOG_LEVEL wins; otherwise production logs at info and every other environment at debug
+func GetLogConfig(serviceName string) *LogConfig {
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = "development"
+	}
+
+	level := os.Getenv("LOG_LEVEL")
+	if level == "" {
+		level = "debug"
+		if environment == "production" {
+			level = "info"
+		}
+	}
+
+	return &LogConfig{
+		Level:       level,
+		ServiceName: serviceName,
+		Environment: environment,
+	}
+}
+
+// GetAnomalyConfig returns the anomaly detection configuration with defaults and environment overrides
+func GetAnomalyConfig() *AnomalyConfig {
+	return &AnomalyConfig{
+		Enabled:        os.Getenv("ANOMALY_DETECTION_ENABLED") != "false",
+		Interval:       30 * time.Second,
+		ShortWindow:    5 * time.Minute,
+		BaselineWindow: time.Hour,
+		ZThreshold:     3.0,
+		MinSamples:     20,
+		MinDrop:        10.0,
+		LogAlerts:      os.Getenv("ALERT_LOG") != "false",
+		WebhookURL:     os.Getenv("ALERT_WEBHOOK_URL"),
+	}
+}
+
+// GetStreamConfig returns the live stream configuration with defaults
+func GetStreamConfig() *StreamConfig {
+	return &StreamConfig{
+		PollInterval: time.Second,
+		Heartbeat:    15 * time.Second,
+	}
+}
+
+// GetSnapshotConfig returns the snapshot configuration with defaults and environment overrides
+func GetSnapshotConfig() *SnapshotConfig {
+	dir := os.Getenv("SNAPSHOT_DIR")
+	if dir == "" {
+		dir = "snapshots"
+	}
+
+	return &SnapshotConfig{
+		Dir:             dir,
+		ConfirmationTTL: time.Minute,
+	}
+}
+
+// GetSecurityConfig returns the authentication and CORS configuration from environment variables
+// Authentication is on everywhere except development unless AUTH_ENABLED says otherwise, and CORS
+// allows any origin in development only
+func GetSecurityConfig() *SecurityConfig {
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = "development"
+	}
+
+	authEnabled := environment != "development"
+	if value := os.Getenv("AUTH_ENABLED"); value != "" {
+		authEnabled = value == "true"
+	}
+
+	origins := splitList(os.Getenv("CORS_ALLOWED_ORIGINS"), ",")
+	if origins == nil && environment == "development" {
+		origins = []string{"*"}
+	}
+
+	return &SecurityConfig{
+		AuthEnabled:        authEnabled,
+		APIKeys:            parseAPIKeys(os.Getenv("API_KEYS")),
+		JWTSecret:          os.Getenv("JWT_SECRET"),
+		JWTIssuer:          os.Getenv("JWT_ISSUER"),
+		CORSAllowedOrigins: origins,
+	}
+}
+
+// parseAPIKeys parses comma-separated "client:key:role1|role2[:tenant]" entries
+// Incomplete entries are kept so the authenticator can reject them at startup
+func parseAPIKeys(value string) []APIKeyConfig {
+	var keys []APIKeyConfig
+	for _, entry := range splitList(value, ",") {
+		parts := strings.SplitN(entry, ":", 4)
+		key := APIKeyConfig{Client: parts[0]}
+		if len(parts) > 1 {
+			key.Key = parts[1]
+		}
+		if len(parts) > 2 {
+			key.Roles = splitList(parts[2], "|")
+		}
+		if len(parts) > 3 {
+			key.Tenant = strings.TrimSpace(parts[3])
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// splitList splits value on sep, trimming spaces and dropping empty items
+func splitList(value, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetRateLimitConfig returns the rate limits from environment variables
+// Limits are written "rate:burst", e.g. RATE_LIMIT_ROUTES="/route=100:200,/route/batch=10:20"
+func GetRateLimitConfig() *RateLimitConfig {
+	cfg := &RateLimitConfig{
+		Enabled: os.Getenv("RATE_LIMIT_ENABLED") != "false",
+		Default: RateLimit{Rate: 200, Burst: 400},
+		Clients: parseRateLimits(os.Getenv("RATE_LIMIT_CLIENTS")),
+		Routes: map[string]RateLimit{
+			"/route":       {Rate: 100, Burst: 200},
+			"/route/batch": {Rate: 10, Burst: 20},
+		},
+	}
+
+	if value := os.Getenv("RATE_LIMIT_DEFAULT"); value != "" {
+		cfg.Default = parseRateLimit(value)
+	}
+	for route, limit := range parseRateLimits(os.Getenv("RATE_LIMIT_ROUTES")) {
+		cfg.Routes[route] = limit
+	}
+
+	return cfg
+}
+
+// parseRateLimits parses comma-separated "name=rate:burst" entries
+// Malformed limits are kept as zero so the limiter can reject them at startup
+func parseRateLimits(value string) map[string]RateLimit {
+	limits := make(map[string]RateLimit)
+	for _, entry := range splitList(value, ",") {
+		name, limit, _ := strings.Cut(entry, "=")
+		limits[strings.TrimSpace(name)] = parseRateLimit(limit)
+	}
+	return limits
+}
+
+// parseRateLimit parses "rate:burst"; the burst defaults to the rate rounded up
+func parseRateLimit(value string) RateLimit {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+
+	var limit RateLimit
+	if parsed, err := strconv.ParseFloat(rate, 64); err == nil {
+		limit.Rate = parsed
+	}
+	if !hasBurst {
+		limit.Burst = int(math.Ceil(limit.Rate))
+	} else if parsed, err := strconv.Atoi(burst); err == nil {
+		limit.Burst = parsed
+	}
+	return limit
+}
+
 // ProcessorsByCountry defines the mapping of countries to their processors
+// These are the processor contracts of DefaultTenant
 var ProcessorsByCountry = map[string][]string{
 	"BR": {"RapidPay_BR", "TurboAcquire_BR", "PayFlow_BR"},
 	"MX": {"RapidPay_MX", "TurboAcquire_MX", "PayFlow_MX"},
 	"CO": {"RapidPay_CO", "TurboAcquire_CO", "PayFlow_CO"},
 }
+
+// DefaultTenant is the business line of transactions, decisions and clients that do not name one
+const DefaultTenant = "rides"
+
+// ProcessorsByTenant defines the processors of each tenant (business line) by country
+// Tenants have their own processor contracts, so their transactions, circuits and stats are kept apart
+var ProcessorsByTenant = map[string]map[string][]string{
+	DefaultTenant: ProcessorsByCountry,
+}
+
+// TenantOrDefault returns tenant, or DefaultTenant when it is empty
+func TenantOrDefault(tenant string) string {
+	if tenant == "" {
+		return DefaultTenant
+	}
+	return tenant
+}
+
+// IsTenant reports whether processors are configured for tenant
+func IsTenant(tenant string) bool {
+	_, exists := ProcessorsByTenant[tenant]
+	return exists
+}
+
+// Tenants returns the configured tenants in a stable order
+func Tenants() []string {
+	tenants := make([]string, 0, len(ProcessorsByTenant))
+	for tenant := range ProcessorsByTenant {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// LoadTenantProcessors adds the tenants in the TENANT_PROCESSORS environment variable to ProcessorsByTenant
+// The value maps tenant to country to processors as JSON, e.g. {"food":{"BR":["RapidPay_BR","PayFlow_BR"]}};
+// a tenant listed there replaces any built-in processors it has
+func LoadTenantProcessors() error {
+	value := os.Getenv("TENANT_PROCESSORS")
+	if value == "" {
+		return nil
+	}
+
+	var tenants map[string]map[string][]string
+	if err := json.Unmarshal([]byte(value), &tenants); err != nil {
+		return fmt.Errorf("TENANT_PROCESSORS must map tenant to country to processors: %w", err)
+	}
+
+	for tenant, countries := range tenants {
+		if tenant == "" || strings.Contains(tenant, ":") {
+			return fmt.Errorf("TENANT_PROCESSORS has invalid tenant name %q", tenant)
+		}
+		if len(countries) == 0 {
+			return fmt.Errorf("TENANT_PROCESSORS has no countries for tenant %s", tenant)
+		}
+		for country, processors := range countries {
+			if len(processors) == 0 {
+				return fmt.Errorf("TENANT_PROCESSORS has no processors for tenant %s in %s", tenant, country)
+			}
+		}
+	}
+
+	for tenant, countries := range tenants {
+		ProcessorsByTenant[tenant] = countries
+	}
+	return nil
+}
+
+// tenantRoutingSettings are a tenant's entry in TENANT_ROUTING_CONFIG; omitted settings keep the defaults
+type tenantRoutingSettings struct {
+	TimeWindow              string   `json:"time_window"` // Go duration, e.g. "30m"
+	HighRiskThreshold       *float64 `json:"high_risk_threshold"`
+	MediumRiskThreshold     *float64 `json:"medium_risk_threshold"`
+	CircuitBreakerThreshold *float64 `json:"circuit_breaker_threshold"`
+	CircuitBreakerTimeout   string   `json:"circuit_breaker_timeout"` // Go duration, e.g. "10m"
+}
+
+// LoadTenantRoutingConfig returns the routing configuration of the tenants in the TENANT_ROUTING_CONFIG
+// environment variable, which maps tenant to settings as JSON, e.g. {"food":{"circuit_breaker_threshold":50}}
+// Settings a tenant does not list keep the values of base; tenants must already be configured
+func LoadTenantRoutingConfig(base *RoutingConfig) (map[string]*RoutingConfig, error) {
+	configs := make(map[string]*RoutingConfig)
+
+	value := os.Getenv("TENANT_ROUTING_CONFIG")
+	if value == "" {
+		return configs, nil
+	}
+
+	var tenants map[string]tenantRoutingSettings
+	if err := json.Unmarshal([]byte(value), &tenants); err != nil {
+		return nil, fmt.Errorf("TENANT_ROUTING_CONFIG must map tenant to routing settings: %w", err)
+	}
+
+	for tenant, settings := range tenants {
+		if !IsTenant(tenant) {
+			return nil, fmt.Errorf("TENANT_ROUTING_CONFIG has unknown tenant %q", tenant)
+		}
+
+		cfg := *base
+		if settings.TimeWindow != "" {
+			window, err := time.ParseDuration(settings.TimeWindow)
+			if err != nil {
+				return nil, fmt.Errorf("TENANT_ROUTING_CONFIG has invalid time_window for tenant %s: %w", tenant, err)
+			}
+			cfg.TimeWindow = window
+		}
+		if settings.CircuitBreakerTimeout != "" {
+			timeout, err := time.ParseDuration(settings.CircuitBreakerTimeout)
+			if err != nil {
+				return nil, fmt.Errorf("TENANT_ROUTING_CONFIG has invalid circuit_breaker_timeout for tenant %s: %w", tenant, err)
+			}
+			cfg.CircuitBreakerTimeout = timeout
+		}
+		if settings.HighRiskThreshold != nil {
+			cfg.HighRiskThreshold = *settings.HighRiskThreshold
+		}
+		if settings.MediumRiskThreshold != nil {
+			cfg.MediumRiskThreshold = *settings.MediumRiskThreshold
+		}
+		if settings.CircuitBreakerThreshold != nil {
+			cfg.CircuitBreakerThreshold = *settings.CircuitBreakerThreshold
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("TENANT_ROUTING_CONFIG has invalid settings for tenant %s: %w", tenant, err)
+		}
+		configs[tenant] = &cfg
+	}
+
+	return configs, nil
+}
+
+// CurrencyByCountry defines the currency accepted for routing in each country
+var CurrencyByCountry = map[string]string{
+	"BR": "BRL",
+	"MX": "MXN",
+	"CO": "COP",
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"net/http"
+	"voltarides/smart-router/alerting"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AlertController exposes processor degradation alerts
+type AlertController struct {
+	detector *alerting.Detector
+}
+
+// NewAlertController creates a new alert controller
+func NewAlertController(detector *alerting.Detector) *AlertController {
+	return &AlertController{detector: detector}
+}
+
+// GetAlerts returns the tenant's alerts filtered by the status query parameter ("active" by default, "resolved" or "all")
+func (ac *AlertController) GetAlerts(c echo.Context) error {
+	status := c.QueryParam("status")
+	switch status {
+	case "":
+		status = models.AlertStatusActive
+	case "all":
+		status = ""
+	case models.AlertStatusActive, models.AlertStatusResolved:
+	default:
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, "status must be active, resolved or all"))
+	}
+
+	return c.JSON(http.StatusOK, models.AlertsResponse{
+		Alerts: ac.detector.Alerts(auth.TenantFromContext(c.Request().Context()), status),
+	})
+}
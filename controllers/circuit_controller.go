@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// CircuitController handles manual circuit breaker control
+type CircuitController struct {
+	service   *services.RoutingService
+	validator *validator.Validate
+}
+
+// NewCircuitController creates a new circuit controller
+func NewCircuitController(service *services.RoutingService) *CircuitController {
+	return &CircuitController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// serviceFor returns the routing service of the tenant the request acts for
+func (cc *CircuitController) serviceFor(c echo.Context) *services.RoutingService {
+	return cc.service.ForTenant(auth.TenantFromContext(c.Request().Context()))
+}
+
+// OpenCircuit forces the circuit of a processor open until the override expires
+func (cc *CircuitController) OpenCircuit(c echo.Context) error {
+	return cc.overrideCircuit(c, models.CircuitOpen)
+}
+
+// CloseCircuit forces the circuit of a processor closed until the override expires
+func (cc *CircuitController) CloseCircuit(c echo.Context) error {
+	return cc.overrideCircuit(c, models.CircuitClosed)
+}
+
+// PinCircuit pins the circuit of a processor to the state given in the request body
+func (cc *CircuitController) PinCircuit(c echo.Context) error {
+	return cc.overrideCircuit(c, "")
+}
+
+// ReleaseCircuit removes a manual override and returns the circuit to automatic control
+func (cc *CircuitController) ReleaseCircuit(c echo.Context) error {
+	processor := c.Param("processor")
+	country := c.Param("country")
+
+	service := cc.serviceFor(c)
+	if !service.HasProcessor(processor, country) {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeProcessorNotFound, "processor "+processor+" not found for country "+country))
+	}
+
+	stat, err := service.ReleaseCircuitOverride(processor, country)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeOverrideNotFound, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, stat)
+}
+
+// GetCircuitHistory returns circuit breaker transitions filtered by processor, country and time range
+func (cc *CircuitController) GetCircuitHistory(c echo.Context) error {
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	events, err := cc.serviceFor(c).GetCircuitHistory(c.QueryParam("processor"), c.QueryParam("country"), from, to)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, models.CircuitHistoryResponse{
+		Events: events,
+	})
+}
+
+// overrideCircuit binds an override request and applies it with the given state
+// An empty state means the state is taken from the request body
+func (cc *CircuitController) overrideCircuit(c echo.Context, state models.CircuitState) error {
+	processor := c.Param("processor")
+	country := c.Param("country")
+
+	service := cc.serviceFor(c)
+	if !service.HasProcessor(processor, country) {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeProcessorNotFound, "processor "+processor+" not found for country "+country))
+	}
+
+	var req models.CircuitOverrideRequest
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	if err := cc.validator.Struct(req); err != nil {
+		return respondError(c, apierror.Validation(err))
+	}
+
+	if state == "" {
+		state = req.State
+	}
+
+	stat, err := service.OverrideCircuit(processor, country, state, req.Reason, req.Operator, req.ExpiresAt)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidOverride, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, stat)
+}
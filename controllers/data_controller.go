@@ -1,40 +1,73 @@
 package controllers
 
 import (
+	"errors"
+	"io/fs"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/config"
 	"voltarides/smart-router/data/generator"
 	"voltarides/smart-router/models"
 	"voltarides/smart-router/storage"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
-// DataController handles test data operations
+// snapshotName restricts snapshot names to plain file names, so requests cannot reach outside the snapshot directory
+var snapshotName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// clearConfirmation is an issued confirmation token for clearing the store
+type clearConfirmation struct {
+	client    string
+	expiresAt time.Time
+}
+
+// DataController handles test data loading and the admin snapshot, restore and clear operations
 type DataController struct {
-	store *storage.InMemoryStore
+	store         *storage.InMemoryStore
+	environment   string
+	snapshots     *config.SnapshotConfig
+	confirmations map[string]clearConfirmation // token -> confirmation
+	mu            sync.Mutex
 }
 
 // NewDataController creates a new data controller
-func NewDataController(store *storage.InMemoryStore) *DataController {
-	return &DataController{store: store}
+// Test data can only be loaded when environment is development
+func NewDataController(store *storage.InMemoryStore, environment string, snapshots *config.SnapshotConfig) *DataController {
+	return &DataController{
+		store:         store,
+		environment:   environment,
+		snapshots:     snapshots,
+		confirmations: make(map[string]clearConfirmation),
+	}
 }
 
 // LoadTestData loads test transactions from the JSON file and adjusts timestamps
+// It replaces everything in the store, so it is refused outside development
 func (dc *DataController) LoadTestData(c echo.Context) error {
+	if dc.environment != "development" {
+		return respondError(c, apierror.New(http.StatusForbidden, apierror.CodeLoadDisabled,
+			"test data can only be loaded in development, not in "+dc.environment))
+	}
+
 	filepath := "data/test_transactions.json"
 
 	// Load transactions from file
 	transactions, err := generator.LoadTransactionsFromFile(filepath)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "load_failed",
-			Message: "Failed to load test data: " + err.Error(),
-		})
+		return respondError(c, apierror.New(http.StatusInternalServerError, apierror.CodeLoadFailed, "Failed to load test data: "+err.Error()))
 	}
 
 	// Adjust timestamps to be relative to current server time
 	// This ensures test data works regardless of when it was generated
-	transactions = generator.AdjustTransactionTimestamps(transactions)
+	transactions = generator.AdjustTransactionTimestamps(transactions, dc.store.Clock())
 
 	// Clear existing data
 	dc.store.Clear()
@@ -47,3 +80,144 @@ func (dc *DataController) LoadTestData(c echo.Context) error {
 		TransactionsLoaded: len(transactions),
 	})
 }
+
+// CreateSnapshot writes the full store to a file in the snapshot directory
+func (dc *DataController) CreateSnapshot(c echo.Context) error {
+	var req models.SnapshotRequest
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	snapshot := dc.store.Snapshot()
+	name := req.Name
+	if name == "" {
+		name = "snapshot-" + snapshot.CreatedAt.UTC().Format("20060102T150405Z") + ".json"
+	}
+	if !snapshotName.MatchString(name) {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSnapshot, "invalid snapshot name "+name))
+	}
+
+	if err := storage.WriteSnapshot(filepath.Join(dc.snapshots.Dir, name), snapshot, req.Overwrite); err != nil {
+		if errors.Is(err, storage.ErrSnapshotExists) {
+			return respondError(c, apierror.New(http.StatusConflict, apierror.CodeSnapshotExists, "snapshot "+name+" already exists"))
+		}
+		return respondError(c, apierror.New(http.StatusInternalServerError, apierror.CodeSnapshotFailed, "Failed to write snapshot: "+err.Error()))
+	}
+
+	logger.FromContext(c.Request().Context()).Info("store snapshot written",
+		"snapshot", name,
+		"client", clientName(c),
+		"transactions", len(snapshot.Transactions),
+	)
+
+	return c.JSON(http.StatusCreated, snapshotResponse(name, snapshot))
+}
+
+// RestoreSnapshot replaces the full store with a snapshot from the snapshot directory
+func (dc *DataController) RestoreSnapshot(c echo.Context) error {
+	name := c.Param("name")
+	if !snapshotName.MatchString(name) {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSnapshot, "invalid snapshot name "+name))
+	}
+
+	snapshot, err := storage.ReadSnapshot(filepath.Join(dc.snapshots.Dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeSnapshotNotFound, "snapshot "+name+" not found"))
+	}
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusInternalServerError, apierror.CodeSnapshotFailed, "Failed to read snapshot: "+err.Error()))
+	}
+
+	if err := dc.store.Restore(snapshot); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSnapshot, err.Error()))
+	}
+
+	logger.FromContext(c.Request().Context()).Warn("store restored from snapshot",
+		"snapshot", name,
+		"client", clientName(c),
+		"transactions", len(snapshot.Transactions),
+	)
+
+	return c.JSON(http.StatusOK, snapshotResponse(name, snapshot))
+}
+
+// ClearStore removes everything from the store in two steps
+// A request without a confirmation token gets one, valid for the configured TTL; repeating the request
+// with that token, as the same client, clears the store
+func (dc *DataController) ClearStore(c echo.Context) error {
+	var req models.ClearStoreRequest
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	client := clientName(c)
+	now := dc.store.Clock().Now()
+
+	if req.ConfirmationToken == "" {
+		confirmation := clearConfirmation{client: client, expiresAt: now.Add(dc.snapshots.ConfirmationTTL)}
+		token := uuid.New().String()
+
+		dc.mu.Lock()
+		for issued, pending := range dc.confirmations {
+			if !now.Before(pending.expiresAt) {
+				delete(dc.confirmations, issued)
+			}
+		}
+		dc.confirmations[token] = confirmation
+		dc.mu.Unlock()
+
+		return c.JSON(http.StatusAccepted, models.ClearStoreConfirmation{
+			Message:           "Send this confirmation token to clear the store",
+			ConfirmationToken: token,
+			ExpiresAt:         confirmation.expiresAt,
+		})
+	}
+
+	// Tokens are single use, whether or not they are still valid
+	dc.mu.Lock()
+	confirmation, ok := dc.confirmations[req.ConfirmationToken]
+	delete(dc.confirmations, req.ConfirmationToken)
+	dc.mu.Unlock()
+
+	if !ok || confirmation.client != client || !now.Before(confirmation.expiresAt) {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidConfirmation, "confirmation token is unknown, expired or already used"))
+	}
+
+	transactions := dc.store.GetTransactionCount()
+	decisions := 0
+	for _, tenant := range config.Tenants() {
+		decisions += dc.store.CountRoutingDecisions(tenant)
+	}
+	dc.store.Clear()
+
+	logger.FromContext(c.Request().Context()).Warn("store cleared",
+		"client", client,
+		"transactions", transactions,
+		"routing_decisions", decisions,
+	)
+
+	return c.JSON(http.StatusOK, models.ClearStoreResponse{
+		Message:                 "Store cleared",
+		TransactionsCleared:     transactions,
+		RoutingDecisionsCleared: decisions,
+	})
+}
+
+// snapshotResponse summarises a snapshot
+func snapshotResponse(name string, snapshot *storage.Snapshot) models.SnapshotResponse {
+	return models.SnapshotResponse{
+		Name:             name,
+		CreatedAt:        snapshot.CreatedAt,
+		Transactions:     len(snapshot.Transactions),
+		RoutingDecisions: len(snapshot.RoutingDecisions),
+		CircuitEvents:    len(snapshot.CircuitEvents),
+	}
+}
+
+// clientName returns the name of the authenticated client, or an empty string for anonymous requests
+func clientName(c echo.Context) string {
+	if principal := auth.FromContext(c.Request().Context()); principal != nil {
+		return principal.Client
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"net/http"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// respondError writes an API error as the JSON error response
+func respondError(c echo.Context, err *apierror.Error) error {
+	return c.JSON(err.Status, err.Response())
+}
+
+// GetErrorCatalog lists every error code the API returns with its HTTP status and gRPC code
+func GetErrorCatalog(c echo.Context) error {
+	return c.JSON(http.StatusOK, models.ErrorCatalogResponse{
+		Errors: apierror.Catalog(),
+	})
+}
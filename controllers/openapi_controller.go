@@ -0,0 +1,13 @@
+package controllers
+
+import (
+	"net/http"
+	"voltarides/smart-router/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetOpenAPISpec returns the OpenAPI 3 document describing the HTTP API
+func GetOpenAPISpec(c echo.Context) error {
+	return c.JSON(http.StatusOK, openapi.Document())
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parseTimeParam parses an optional RFC3339 query parameter, returning the zero time when absent
+func parseTimeParam(c echo.Context, name string) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp", name)
+	}
+
+	return parsed, nil
+}
+
+// parseIntParam parses an optional integer query parameter, returning fallback when absent
+func parseIntParam(c echo.Context, name string, fallback int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", name)
+	}
+
+	return parsed, nil
+}
+
+// parseDurationParam parses an optional Go duration query parameter (e.g. "5m"), returning zero when absent
+func parseDurationParam(c echo.Context, name string) (time.Duration, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 5m or 1h", name)
+	}
+
+	return parsed, nil
+}
@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
 	"voltarides/smart-router/models"
 	"voltarides/smart-router/services"
 
@@ -23,23 +26,22 @@ func NewRoutingController(service *services.RoutingService) *RoutingController {
 	}
 }
 
+// serviceFor returns the routing service of the tenant the request acts for
+func (rc *RoutingController) serviceFor(c echo.Context) *services.RoutingService {
+	return rc.service.ForTenant(auth.TenantFromContext(c.Request().Context()))
+}
+
 // RouteTransaction handles routing decision requests
 func (rc *RoutingController) RouteTransaction(c echo.Context) error {
 	var req models.RoutingRequest
 
 	// Bind and validate request
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body: " + err.Error(),
-		})
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
 	}
 
 	if err := rc.validator.Struct(req); err != nil {
-		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error:   "validation_failed",
-			Message: "Request validation failed: " + err.Error(),
-		})
+		return respondError(c, apierror.Validation(err))
 	}
 
 	// Check if simulation mode is enabled
@@ -49,27 +51,121 @@ func (rc *RoutingController) RouteTransaction(c echo.Context) error {
 	failover := c.QueryParam("failover") == "true"
 
 	// Get routing decision
+	service := rc.serviceFor(c)
 	var response *models.RoutingResponse
 	var err error
 	if failover {
-		response, err = rc.service.SelectBestProcessorWithFailover(req, simulate)
+		response, err = service.SelectBestProcessorWithFailover(c.Request().Context(), req, simulate)
 	} else {
-		response, err = rc.service.SelectBestProcessor(req, simulate)
+		response, err = service.SelectBestProcessor(c.Request().Context(), req, simulate)
 	}
 	if err != nil {
-		// Check if it's an unsupported country error
-		if err.Error() == "country "+req.Country+" not supported" {
-			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "unsupported_country",
-				Message: err.Error(),
-			})
+		return respondError(c, apierror.Routing(err))
+	}
+
+	// Picked up by the logging middleware
+	c.Set("processor", response.Processor)
+	c.Set("risk_level", response.RiskLevel)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// maxBatchRequests caps the number of requests routed in a single batch call
+const maxBatchRequests = 1000
+
+// RouteBatch routes an array of requests and returns a result or an error for each, in request order
+// Invalid items are reported individually and do not fail the batch
+func (rc *RoutingController) RouteBatch(c echo.Context) error {
+	var requests []models.RoutingRequest
+
+	if err := c.Bind(&requests); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	if len(requests) == 0 || len(requests) > maxBatchRequests {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed,
+			fmt.Sprintf("Between 1 and %d routing requests are required", maxBatchRequests)))
+	}
+
+	response := models.BatchRoutingResponse{
+		Results: make([]models.BatchRoutingResult, len(requests)),
+	}
+
+	// Only valid requests reach the service; indices map its results back to the batch
+	valid := make([]models.RoutingRequest, 0, len(requests))
+	indices := make([]int, 0, len(requests))
+	for i, req := range requests {
+		response.Results[i].Index = i
+		if err := rc.validator.Struct(req); err != nil {
+			apiErr := apierror.Validation(err).Response()
+			response.Results[i].Error = &apiErr
+			continue
 		}
+		valid = append(valid, req)
+		indices = append(indices, i)
+	}
+
+	simulate := c.QueryParam("simulate") == "true"
+	failover := c.QueryParam("failover") == "true"
 
-		// Check if it's a no processors available error
-		return c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
-			Error:   "no_processors_available",
-			Message: err.Error(),
-		})
+	decisions, errs := rc.serviceFor(c).SelectBestProcessorBatch(c.Request().Context(), valid, simulate, failover)
+	for j, i := range indices {
+		if errs[j] != nil {
+			apiErr := apierror.Routing(errs[j]).Response()
+			response.Results[i].Error = &apiErr
+			continue
+		}
+		response.Results[i].Decision = decisions[j]
+	}
+
+	for _, result := range response.Results {
+		if result.Error != nil {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// maxWhatIfRequests caps the number of requests evaluated in a single what-if call
+const maxWhatIfRequests = 500
+
+// WhatIfRouting evaluates routing requests against a hypothetical configuration
+func (rc *RoutingController) WhatIfRouting(c echo.Context) error {
+	var req models.WhatIfRequest
+
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	requests := req.Requests
+	if req.Request != nil {
+		requests = append([]models.RoutingRequest{*req.Request}, requests...)
+	}
+
+	if len(requests) == 0 || len(requests) > maxWhatIfRequests {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, fmt.Sprintf("Between 1 and %d routing requests are required", maxWhatIfRequests)))
+	}
+
+	for i, routingRequest := range requests {
+		if err := rc.validator.Struct(routingRequest); err != nil {
+			return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, fmt.Sprintf("Request %d validation failed: %s", i, err.Error())))
+		}
+	}
+
+	response, err := rc.serviceFor(c).WhatIf(c.Request().Context(), req.Config, requests)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidConfig, err.Error()))
+	}
+
+	// Report per-request failures with the same codes as POST /route
+	for i := range response.Results {
+		if response.Results[i].Err != nil {
+			apiErr := apierror.Routing(response.Results[i].Err).Response()
+			response.Results[i].Error = &apiErr
+		}
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -77,7 +173,7 @@ func (rc *RoutingController) RouteTransaction(c echo.Context) error {
 
 // GetProcessorHealth returns health stats for all processors
 func (rc *RoutingController) GetProcessorHealth(c echo.Context) error {
-	stats := rc.service.GetAllProcessorStats()
+	stats := rc.serviceFor(c).GetAllProcessorStats()
 
 	return c.JSON(http.StatusOK, models.ProcessorHealthResponse{
 		Processors: stats,
@@ -88,20 +184,162 @@ func (rc *RoutingController) GetProcessorHealth(c echo.Context) error {
 func (rc *RoutingController) GetProcessorByName(c echo.Context) error {
 	name := c.Param("name")
 
-	stat, err := rc.service.GetProcessorStats(name)
+	stat, err := rc.serviceFor(c).GetProcessorStats(name)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Error:   "processor_not_found",
-			Message: err.Error(),
-		})
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeProcessorNotFound, err.Error()))
 	}
 
 	return c.JSON(http.StatusOK, stat)
 }
 
+// GetProcessorTimeSeries returns a processor's approval rate per time bucket with circuit state overlays
+func (rc *RoutingController) GetProcessorTimeSeries(c echo.Context) error {
+	name := c.Param("name")
+
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	bucket, err := parseDurationParam(c, "bucket")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	service := rc.serviceFor(c)
+	if _, exists := service.ProcessorCountry(name); !exists {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeProcessorNotFound, "processor "+name+" not found"))
+	}
+
+	series, err := service.GetProcessorTimeSeries(name, from, to, bucket)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, series)
+}
+
 // GetRoutingStats returns routing decision statistics
+// Supports country, from / to (RFC3339), limit and bucket (Go duration) query parameters
 func (rc *RoutingController) GetRoutingStats(c echo.Context) error {
-	stats := rc.service.GetRoutingStats()
+	query := models.RoutingStatsQuery{Country: c.QueryParam("country")}
+
+	var err error
+	if query.From, err = parseTimeParam(c, "from"); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	if query.To, err = parseTimeParam(c, "to"); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	if query.Limit, err = parseIntParam(c, "limit", 0); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	if query.Bucket, err = parseDurationParam(c, "bucket"); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	stats, err := rc.serviceFor(c).GetRoutingStats(query)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
 
 	return c.JSON(http.StatusOK, stats)
 }
+
+// defaultDecisionPageSize is the page size of the decision audit log when no limit is given
+const defaultDecisionPageSize = 50
+
+// GetRoutingDecisions returns a page of the routing decision audit log
+func (rc *RoutingController) GetRoutingDecisions(c echo.Context) error {
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	offset, err := parseIntParam(c, "offset", 0)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	limit, err := parseIntParam(c, "limit", defaultDecisionPageSize)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	response, err := rc.serviceFor(c).GetRoutingDecisions(c.QueryParam("processor"), c.QueryParam("country"), from, to, offset, limit)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetRoutingDecisionByID returns a single routing decision with its explanation
+func (rc *RoutingController) GetRoutingDecisionByID(c echo.Context) error {
+	decision, err := rc.serviceFor(c).GetRoutingDecision(c.Param("id"))
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeDecisionNotFound, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, decision)
+}
+
+// RecordTransactionOutcome ingests the outcome of a payment, optionally linked to a routing decision
+func (rc *RoutingController) RecordTransactionOutcome(c echo.Context) error {
+	var req models.TransactionOutcomeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	if err := rc.validator.Struct(req); err != nil {
+		return respondError(c, apierror.Validation(err))
+	}
+
+	service := rc.serviceFor(c)
+	if req.DecisionID != "" {
+		if _, err := service.GetRoutingDecision(req.DecisionID); err != nil {
+			return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeDecisionNotFound, err.Error()))
+		}
+	}
+
+	tx, err := service.RecordOutcome(req)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidOutcome, err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, tx)
+}
+
+// GetRoutingEffectiveness returns how routed traffic performed against the router's predictions
+func (rc *RoutingController) GetRoutingEffectiveness(c echo.Context) error {
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	response, err := rc.serviceFor(c).GetRoutingEffectiveness(c.QueryParam("country"), from, to)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/stream"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StreamController pushes live processor health to dashboards over Server-Sent Events
+type StreamController struct {
+	hubs      map[string]*stream.Hub // key: tenant
+	heartbeat time.Duration
+}
+
+// NewStreamController creates a new stream controller over one hub per tenant, sending heartbeats at the given interval
+func NewStreamController(hubs []*stream.Hub, heartbeat time.Duration) *StreamController {
+	controller := &StreamController{
+		hubs:      make(map[string]*stream.Hub, len(hubs)),
+		heartbeat: heartbeat,
+	}
+	for _, hub := range hubs {
+		controller.hubs[hub.Tenant()] = hub
+	}
+	return controller
+}
+
+// StreamProcessorHealth streams the tenant's processor stats, routing distribution and router events,
+// optionally for one country
+// The stream starts with a snapshot of the current state and then only carries changes
+func (sc *StreamController) StreamProcessorHealth(c echo.Context) error {
+	tenant := auth.TenantFromContext(c.Request().Context())
+	hub, exists := sc.hubs[tenant]
+	if !exists {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeUnknownTenant, "no stream for tenant "+tenant))
+	}
+
+	country := c.QueryParam("country")
+	if country != "" && !slices.Contains(hub.Countries(), country) {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeUnsupportedCountry, "country "+country+" not supported"))
+	}
+
+	// Subscribe before taking the snapshot so no change falls between the two
+	messages, unsubscribe := hub.Subscribe(country)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	for _, message := range hub.Snapshot(country) {
+		if err := writeEvent(res, message.Type, message.Data); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(sc.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case message, open := <-messages:
+			if !open {
+				// Dropped for falling behind; the client reconnects and starts from a new snapshot
+				return nil
+			}
+			if err := writeEvent(res, message.Type, message.Data); err != nil {
+				return nil
+			}
+		case now := <-heartbeat.C:
+			if err := writeEvent(res, models.StreamHeartbeat, models.StreamHeartbeatMessage{Timestamp: now}); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload
+func writeEvent(res *echo.Response, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"net/http"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/webhooks"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookController manages webhook subscriptions and exposes their delivery log
+type WebhookController struct {
+	dispatcher *webhooks.Dispatcher
+	validator  *validator.Validate
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(dispatcher *webhooks.Dispatcher) *WebhookController {
+	return &WebhookController{
+		dispatcher: dispatcher,
+		validator:  validator.New(),
+	}
+}
+
+// CreateSubscription subscribes a URL to router events; the response is the only place the secret is returned
+func (wc *WebhookController) CreateSubscription(c echo.Context) error {
+	var req models.WebhookSubscriptionRequest
+	if err := c.Bind(&req); err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+err.Error()))
+	}
+
+	if err := wc.validator.Struct(req); err != nil {
+		return respondError(c, apierror.Validation(err))
+	}
+
+	subscription, err := wc.dispatcher.Subscribe(auth.TenantFromContext(c.Request().Context()), req)
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSubscription, err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, subscription)
+}
+
+// GetSubscriptions lists webhook subscriptions
+func (wc *WebhookController) GetSubscriptions(c echo.Context) error {
+	return c.JSON(http.StatusOK, models.WebhookSubscriptionsResponse{
+		Subscriptions: wc.dispatcher.Subscriptions(auth.TenantFromContext(c.Request().Context())),
+	})
+}
+
+// DeleteSubscription removes a webhook subscription
+func (wc *WebhookController) DeleteSubscription(c echo.Context) error {
+	id := c.Param("id")
+	if !wc.dispatcher.Unsubscribe(auth.TenantFromContext(c.Request().Context()), id) {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeSubscriptionNotFound, "webhook subscription "+id+" not found"))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDeliveries returns the delivery log, filtered by the subscription_id and status query parameters
+func (wc *WebhookController) GetDeliveries(c echo.Context) error {
+	status := c.QueryParam("status")
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryRetrying, models.DeliveryDelivered, models.DeliveryDeadLettered:
+	default:
+		return respondError(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, "status must be pending, retrying, delivered or dead_lettered"))
+	}
+
+	return c.JSON(http.StatusOK, models.WebhookDeliveriesResponse{
+		Deliveries: wc.dispatcher.Deliveries(auth.TenantFromContext(c.Request().Context()), c.QueryParam("subscription_id"), status),
+	})
+}
+
+// GetDeadLetters returns deliveries that exhausted their retries
+func (wc *WebhookController) GetDeadLetters(c echo.Context) error {
+	return c.JSON(http.StatusOK, models.WebhookDeliveriesResponse{
+		Deliveries: wc.dispatcher.DeadLetters(auth.TenantFromContext(c.Request().Context())),
+	})
+}
+
+// RetryDeadLetter redelivers a dead-lettered delivery
+func (wc *WebhookController) RetryDeadLetter(c echo.Context) error {
+	delivery, err := wc.dispatcher.Redeliver(auth.TenantFromContext(c.Request().Context()), c.Param("id"))
+	if err != nil {
+		return respondError(c, apierror.New(http.StatusNotFound, apierror.CodeDeadLetterNotFound, err.Error()))
+	}
+
+	return c.JSON(http.StatusAccepted, delivery)
+}
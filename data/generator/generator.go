@@ -6,15 +6,16 @@ import (
 	"math/rand"
 	"os"
 	"time"
+	"voltarides/smart-router/common/clock"
 	"voltarides/smart-router/models"
 
 	"github.com/google/uuid"
 )
 
-// GenerateTestTransactions creates realistic test transaction data
-func GenerateTestTransactions(count int) []models.Transaction {
+// GenerateTestTransactions creates realistic test transaction data ending at the clock's current time
+func GenerateTestTransactions(count int, clk clock.Clock) []models.Transaction {
 	transactions := make([]models.Transaction, 0, count)
-	now := time.Now()
+	now := clk.Now()
 
 	// Define processor configurations
 	processorConfigs := []struct {
@@ -121,14 +122,14 @@ func LoadTransactionsFromFile(filepath string) ([]models.Transaction, error) {
 	return dataset.Transactions, nil
 }
 
-// AdjustTransactionTimestamps adjusts transaction timestamps to be relative to current server time
+// AdjustTransactionTimestamps adjusts transaction timestamps to be relative to the clock's current time
 // This ensures test data works regardless of when it was originally generated
-func AdjustTransactionTimestamps(transactions []models.Transaction) []models.Transaction {
+func AdjustTransactionTimestamps(transactions []models.Transaction, clk clock.Clock) []models.Transaction {
 	if len(transactions) == 0 {
 		return transactions
 	}
 
-	now := time.Now()
+	now := clk.Now()
 
 	// Find the most recent (latest) timestamp in the transactions
 	var latestTimestamp time.Time
@@ -0,0 +1,100 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types published by the router
+const (
+	CircuitOpened      = "circuit.opened"
+	CircuitClosed      = "circuit.closed"
+	CircuitHalfOpened  = "circuit.half_opened"
+	CountryUnavailable = "country.no_processors"
+	CountryHighRisk    = "country.high_risk"
+	CountryRecovered   = "country.recovered"
+)
+
+// Types lists every event type, for validating subscriptions
+var Types = []string{CircuitOpened, CircuitClosed, CircuitHalfOpened, CountryUnavailable, CountryHighRisk, CountryRecovered}
+
+// Event is a notification about something that happened in the router
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Tenant    string      `json:"tenant,omitempty"` // Tenant the event concerns
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// New creates an event with a fresh ID
+func New(eventType string, timestamp time.Time, data interface{}) Event {
+	return Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Timestamp: timestamp,
+		Data:      data,
+	}
+}
+
+// IsValidType reports whether eventType is a known event type
+func IsValidType(eventType string) bool {
+	for _, known := range Types {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler receives published events; it runs on the publisher's goroutine and must not block
+type Handler func(Event)
+
+// Bus fans events out to subscribed handlers in-process
+// A nil *Bus is valid and drops every event
+type Bus struct {
+	handlers map[int]Handler
+	nextID   int
+	mu       sync.RWMutex
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[int]Handler)}
+}
+
+// Subscribe registers a handler and returns a function that removes it
+func (b *Bus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish delivers an event to every handler
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
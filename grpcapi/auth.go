@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/proto/routerpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys carrying credentials and the requested tenant, matching the HTTP X-API-Key,
+// Authorization and X-Tenant headers
+const (
+	apiKeyKey        = "x-api-key"
+	authorizationKey = "authorization"
+	tenantKey        = "x-tenant"
+)
+
+// methodRoles lists the roles allowed to call each router method, as ConfigRouter does for HTTP routes
+// Methods of other services (health checks, reflection) are public
+var methodRoles = map[string][]string{
+	routerpb.RouterService_Route_FullMethodName:              {auth.RoleRouterClient},
+	routerpb.RouterService_GetProcessorStats_FullMethodName:  {auth.RoleRouterClient, auth.RoleOperator},
+	routerpb.RouterService_GetRoutingStats_FullMethodName:    {auth.RoleRouterClient, auth.RoleOperator},
+	routerpb.RouterService_RecordTransaction_FullMethodName:  {auth.RoleIngestWriter},
+	routerpb.RouterService_IngestTransactions_FullMethodName: {auth.RoleIngestWriter},
+}
+
+// UnaryAuthInterceptor authenticates the caller and checks the roles of the called method
+func UnaryAuthInterceptor(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor authenticates the caller of a streaming method and checks its roles
+func StreamAuthInterceptor(authenticator *auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(stream.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorize returns ctx carrying the caller's principal and tenant, or the error to answer with
+func authorize(ctx context.Context, authenticator *auth.Authenticator, method string) (context.Context, error) {
+	roles, protected := methodRoles[method]
+	if !protected {
+		return ctx, nil
+	}
+
+	var apiKey, token, requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(apiKeyKey); len(values) > 0 {
+			apiKey = values[0]
+		}
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			if scheme, credentials, found := strings.Cut(values[0], " "); found && strings.EqualFold(scheme, "Bearer") {
+				token = strings.TrimSpace(credentials)
+			}
+		}
+		if values := md.Get(tenantKey); len(values) > 0 {
+			requested = values[0]
+		}
+	}
+
+	principal, err := authenticator.Authenticate(apiKey, token)
+	if err != nil {
+		return ctx, apierror.Unauthorized(err)
+	}
+	if !principal.HasRole(roles...) {
+		return ctx, apierror.Forbidden(principal.Client, roles)
+	}
+
+	tenant, err := auth.ResolveTenant(principal, requested)
+	if err != nil {
+		return ctx, apierror.Tenant(principal.Client, requested, err)
+	}
+
+	return auth.WithTenant(auth.WithPrincipal(ctx, principal), tenant), nil
+}
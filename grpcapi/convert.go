@@ -0,0 +1,153 @@
+package grpcapi
+
+import (
+	"time"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/proto/routerpb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toRouteResponse converts a routing decision to its protobuf form
+func toRouteResponse(response *models.RoutingResponse) *routerpb.RouteResponse {
+	converted := &routerpb.RouteResponse{
+		DecisionId:   response.DecisionID,
+		Processor:    response.Processor,
+		ApprovalRate: response.ApprovalRate,
+		RiskLevel:    response.RiskLevel,
+		Reason:       response.Reason,
+		Timestamp:    response.Timestamp,
+		Fallback:     toProcessorOption(response.Fallback),
+		LastResort:   toProcessorOption(response.LastResort),
+	}
+
+	for _, event := range response.SimulatedTransitions {
+		converted.SimulatedTransitions = append(converted.SimulatedTransitions, toCircuitEvent(event))
+	}
+
+	return converted
+}
+
+func toProcessorOption(option *models.ProcessorOption) *routerpb.ProcessorOption {
+	if option == nil {
+		return nil
+	}
+	return &routerpb.ProcessorOption{
+		Processor:    option.Processor,
+		ApprovalRate: option.ApprovalRate,
+	}
+}
+
+func toCircuitEvent(event models.CircuitEvent) *routerpb.CircuitEvent {
+	return &routerpb.CircuitEvent{
+		Processor:    event.Processor,
+		Country:      event.Country,
+		FromState:    string(event.FromState),
+		ToState:      string(event.ToState),
+		ApprovalRate: event.ApprovalRate,
+		Trigger:      event.Trigger,
+		Operator:     event.Operator,
+		Reason:       event.Reason,
+		Timestamp:    timestamppb.New(event.Timestamp),
+	}
+}
+
+func toProcessorStats(stat models.ProcessorStats) *routerpb.ProcessorStats {
+	converted := &routerpb.ProcessorStats{
+		Name:             stat.Name,
+		Country:          stat.Country,
+		ApprovalRate:     stat.ApprovalRate,
+		TransactionCount: int32(stat.TransactionCount),
+		LastUpdated:      stat.LastUpdated,
+		CircuitState:     string(stat.CircuitState),
+		CircuitOpenedAt:  stat.CircuitOpenedAt,
+	}
+
+	if stat.CircuitOverride != nil {
+		converted.CircuitOverride = &routerpb.CircuitOverride{
+			State:     string(stat.CircuitOverride.State),
+			Reason:    stat.CircuitOverride.Reason,
+			Operator:  stat.CircuitOverride.Operator,
+			CreatedAt: stat.CircuitOverride.CreatedAt,
+			ExpiresAt: stat.CircuitOverride.ExpiresAt,
+		}
+	}
+
+	return converted
+}
+
+func toRoutingStats(stats *models.RoutingStats) *routerpb.RoutingStats {
+	converted := &routerpb.RoutingStats{
+		TotalDecisions:  int32(stats.TotalDecisions),
+		Distribution:    toCounts(stats.Distribution),
+		Window:          stats.Window,
+		Decisions:       int32(stats.Decisions),
+		From:            toTimestamp(stats.From),
+		To:              toTimestamp(stats.To),
+		Share:           stats.Share,
+		RiskLevels:      toCounts(stats.RiskLevels),
+		AvgApprovalRate: stats.AvgApprovalRate,
+		Fallback: &routerpb.FallbackUsage{
+			DecisionsWithOutcome: int32(stats.Fallback.DecisionsWithOutcome),
+			FallbackUsed:         int32(stats.Fallback.FallbackUsed),
+			Rate:                 stats.Fallback.Rate,
+		},
+		Bucket: stats.Bucket,
+	}
+
+	for _, bucket := range stats.TimeSeries {
+		converted.TimeSeries = append(converted.TimeSeries, &routerpb.RoutingStatsBucket{
+			Start:        timestamppb.New(bucket.Start),
+			Decisions:    int32(bucket.Decisions),
+			Distribution: toCounts(bucket.Distribution),
+		})
+	}
+
+	return converted
+}
+
+func toTransaction(tx *models.Transaction) *routerpb.Transaction {
+	return &routerpb.Transaction{
+		Id:         tx.ID,
+		Processor:  tx.Processor,
+		Country:    tx.Country,
+		Currency:   tx.Currency,
+		Amount:     tx.Amount,
+		Status:     tx.Status,
+		Timestamp:  timestamppb.New(tx.Timestamp),
+		DecisionId: tx.DecisionID,
+	}
+}
+
+func fromTransactionOutcome(req *routerpb.TransactionOutcome) models.TransactionOutcomeRequest {
+	outcome := models.TransactionOutcomeRequest{
+		ID:         req.GetId(),
+		DecisionID: req.GetDecisionId(),
+		Processor:  req.GetProcessor(),
+		Country:    req.GetCountry(),
+		Currency:   req.GetCurrency(),
+		Amount:     req.GetAmount(),
+		Status:     req.GetStatus(),
+	}
+	if req.GetTimestamp() != nil {
+		outcome.Timestamp = req.GetTimestamp().AsTime()
+	}
+	return outcome
+}
+
+// toCounts converts a count map to the int32 values used on the wire
+func toCounts(counts map[string]int) map[string]int32 {
+	converted := make(map[string]int32, len(counts))
+	for key, count := range counts {
+		converted[key] = int32(count)
+	}
+	return converted
+}
+
+// toTimestamp converts an optional time, leaving the field unset when absent
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
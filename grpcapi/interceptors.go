@@ -0,0 +1,95 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/telemetry"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata key carrying the caller's request ID, as X-Request-Id does over HTTP
+const requestIDKey = "x-request-id"
+
+// UnaryInterceptor traces each call and writes one structured access log entry, like the HTTP middleware stack
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, finish := startCall(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		finish(err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor traces each streaming call and writes one access log entry when it ends
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, finish := startCall(stream.Context(), info.FullMethod)
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		finish(err)
+		return err
+	}
+}
+
+// startCall starts the span and request-scoped logger of a call and returns a function that ends them
+func startCall(ctx context.Context, method string) (context.Context, func(error)) {
+	start := time.Now()
+
+	ctx, span := telemetry.StartSpan(ctx, "grpc.request")
+	span.SetAttribute("rpc.method", method)
+
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(requestIDKey)) > 0 {
+		requestID = md.Get(requestIDKey)[0]
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	requestLogger := logger.FromContext(ctx).With("request_id", requestID, "trace_id", span.TraceID())
+	ctx = logger.WithContext(ctx, requestLogger)
+
+	return ctx, func(err error) {
+		defer span.End()
+
+		code := status.Code(err)
+		span.SetAttribute("rpc.grpc.status_code", code.String())
+
+		attrs := []any{
+			"method", method,
+			"code", code.String(),
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000.0,
+		}
+
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		if err != nil {
+			span.RecordError(err)
+			attrs = append(attrs, "error", err.Error())
+		}
+
+		requestLogger.Log(ctx, level, "grpc request", attrs...)
+	}
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,82 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// methodRoutes maps router methods to their HTTP routes, so both APIs share route limits and quotas
+var methodRoutes = map[string]string{
+	routerpb.RouterService_Route_FullMethodName:              constants.Route,
+	routerpb.RouterService_GetProcessorStats_FullMethodName:  constants.Processors,
+	routerpb.RouterService_GetRoutingStats_FullMethodName:    constants.RoutingStats,
+	routerpb.RouterService_RecordTransaction_FullMethodName:  constants.Transactions,
+	routerpb.RouterService_IngestTransactions_FullMethodName: constants.Transactions,
+}
+
+// UnaryRateLimitInterceptor rejects calls over the caller's quota or the method's route limit with ResourceExhausted
+// It must run after the auth interceptor, which identifies the caller
+func UnaryRateLimitInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, limiter, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor limits the opening of streams; messages within a stream are not limited
+func StreamRateLimitInterceptor(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allow(stream.Context(), limiter, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// allow returns the error to answer with when the call is over its limits, setting the retry-after trailer
+func allow(ctx context.Context, limiter *ratelimit.Limiter, method string) error {
+	route, limited := methodRoutes[method]
+	if !limited || !limiter.Enabled() {
+		return nil
+	}
+
+	client := ""
+	if principal := auth.FromContext(ctx); principal != nil && principal.Method != auth.MethodAnonymous {
+		client = principal.Client
+	}
+
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	decision := limiter.Allow(client, ip, route)
+	if decision.Allowed {
+		return nil
+	}
+
+	if client == "" {
+		client = auth.MethodAnonymous
+	}
+	metrics.RecordRateLimited(route, client, decision.Scope)
+
+	retryAfter := decision.RetryAfterSeconds()
+	grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+	return apierror.RateLimited(decision.Scope, route, retryAfter)
+}
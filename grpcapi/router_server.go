@@ -0,0 +1,181 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/services"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxIngestErrors caps the errors reported by a single ingest stream; rejections beyond it are only counted
+const maxIngestErrors = 100
+
+// RouterServer implements the gRPC RouterService on top of the routing service
+// Requests are validated with the same rules as the HTTP API and errors carry the same codes, and
+// each call is served for the tenant the auth interceptor resolved
+type RouterServer struct {
+	routerpb.UnimplementedRouterServiceServer
+	service   *services.RoutingService
+	validator *validator.Validate
+}
+
+// NewRouterServer creates a new gRPC router server
+func NewRouterServer(service *services.RoutingService) *RouterServer {
+	return &RouterServer{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// Route selects the best processor for a transaction
+func (rs *RouterServer) Route(ctx context.Context, req *routerpb.RouteRequest) (*routerpb.RouteResponse, error) {
+	routingRequest := models.RoutingRequest{
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+		Country:  req.GetCountry(),
+	}
+
+	if err := rs.validator.Struct(routingRequest); err != nil {
+		return nil, apierror.Validation(err)
+	}
+
+	service := rs.tenantService(ctx)
+	var response *models.RoutingResponse
+	var err error
+	if req.GetFailover() {
+		response, err = service.SelectBestProcessorWithFailover(ctx, routingRequest, req.GetSimulate())
+	} else {
+		response, err = service.SelectBestProcessor(ctx, routingRequest, req.GetSimulate())
+	}
+	if err != nil {
+		return nil, apierror.Routing(err)
+	}
+
+	return toRouteResponse(response), nil
+}
+
+// GetProcessorStats returns the stats of one processor, or of every processor optionally filtered by country
+func (rs *RouterServer) GetProcessorStats(ctx context.Context, req *routerpb.GetProcessorStatsRequest) (*routerpb.GetProcessorStatsResponse, error) {
+	service := rs.tenantService(ctx)
+	if country := req.GetCountry(); country != "" && !slices.Contains(service.Countries(), country) {
+		return nil, apierror.New(http.StatusBadRequest, apierror.CodeUnsupportedCountry, "country "+country+" not supported")
+	}
+
+	var stats []models.ProcessorStats
+	if name := req.GetName(); name != "" {
+		stat, err := service.GetProcessorStats(name)
+		if err != nil {
+			return nil, apierror.New(http.StatusNotFound, apierror.CodeProcessorNotFound, err.Error())
+		}
+		stats = []models.ProcessorStats{*stat}
+	} else {
+		stats = service.GetAllProcessorStats()
+	}
+
+	response := &routerpb.GetProcessorStatsResponse{}
+	for _, stat := range stats {
+		if req.GetCountry() == "" || stat.Country == req.GetCountry() {
+			response.Processors = append(response.Processors, toProcessorStats(stat))
+		}
+	}
+
+	return response, nil
+}
+
+// GetRoutingStats returns routing decision statistics
+func (rs *RouterServer) GetRoutingStats(ctx context.Context, req *routerpb.GetRoutingStatsRequest) (*routerpb.RoutingStats, error) {
+	query := models.RoutingStatsQuery{
+		Country: req.GetCountry(),
+		Limit:   int(req.GetLimit()),
+	}
+	if req.GetFrom() != nil {
+		query.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		query.To = req.GetTo().AsTime()
+	}
+	if req.GetBucket() != nil {
+		query.Bucket = req.GetBucket().AsDuration()
+	}
+
+	stats, err := rs.tenantService(ctx).GetRoutingStats(query)
+	if err != nil {
+		return nil, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error())
+	}
+
+	return toRoutingStats(stats), nil
+}
+
+// RecordTransaction reports the outcome of a payment
+func (rs *RouterServer) RecordTransaction(ctx context.Context, req *routerpb.TransactionOutcome) (*routerpb.Transaction, error) {
+	tx, apiErr := rs.recordOutcome(rs.tenantService(ctx), req)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return toTransaction(tx), nil
+}
+
+// IngestTransactions records a stream of payment outcomes, reporting invalid ones instead of aborting
+func (rs *RouterServer) IngestTransactions(stream routerpb.RouterService_IngestTransactionsServer) error {
+	response := &routerpb.IngestTransactionsResponse{}
+	service := rs.tenantService(stream.Context())
+
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(response)
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, apiErr := rs.recordOutcome(service, req); apiErr != nil {
+			response.Rejected++
+			if len(response.Errors) < maxIngestErrors {
+				response.Errors = append(response.Errors, &routerpb.IngestError{
+					Index:   index,
+					Code:    apiErr.Code,
+					Message: apiErr.Message,
+				})
+			}
+			continue
+		}
+		response.Recorded++
+	}
+}
+
+// recordOutcome validates and stores a payment outcome the same way POST /transactions does
+func (rs *RouterServer) recordOutcome(service *services.RoutingService, req *routerpb.TransactionOutcome) (*models.Transaction, *apierror.Error) {
+	outcome := fromTransactionOutcome(req)
+
+	if err := rs.validator.Struct(outcome); err != nil {
+		return nil, apierror.Validation(err)
+	}
+
+	if outcome.DecisionID != "" {
+		if _, err := service.GetRoutingDecision(outcome.DecisionID); err != nil {
+			return nil, apierror.New(http.StatusNotFound, apierror.CodeDecisionNotFound, err.Error())
+		}
+	}
+
+	tx, err := service.RecordOutcome(outcome)
+	if err != nil {
+		return nil, apierror.New(http.StatusBadRequest, apierror.CodeInvalidOutcome, err.Error())
+	}
+
+	return tx, nil
+}
+
+// tenantService returns the routing service of the tenant the call acts for
+func (rs *RouterServer) tenantService(ctx context.Context) *services.RoutingService {
+	return rs.service.ForTenant(auth.TenantFromContext(ctx))
+}
@@ -1,18 +1,31 @@
 package main
 
 import (
+	"context"
+	"log"
 	"voltarides/smart-router/cmd/httpServer"
 	"voltarides/smart-router/common/constants"
-
-	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/telemetry"
 )
 
 func main() {
-	// Start DataDog tracer (Yuno standard)
-	tracer.Start(
-		tracer.WithService(constants.MicroserviceName),
-	)
-	defer tracer.Stop()
+	// Structured JSON logs; the standard log package is routed through the same handler
+	if _, err := logger.Init(config.GetLogConfig(constants.MicroserviceName)); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	// Start tracing (DataDog by default, OTLP when TELEMETRY_EXPORTER=otlp)
+	tracer, err := telemetry.Init(config.GetTelemetryConfig(constants.MicroserviceName))
+	if err != nil {
+		log.Fatalf("Failed to initialize telemetry: %v", err)
+	}
+	defer func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			log.Printf("Failed to flush traces: %v", err)
+		}
+	}()
 
 	// Initialize and start server
 	server := httpServer.EchoServer{}
@@ -0,0 +1,198 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"voltarides/smart-router/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric exported by the router
+const namespace = "volta_router"
+
+// Registry holds all router metrics exposed on /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	routingDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "routing_decisions_total",
+			Help:      "Routing decisions recorded, by tenant, country, processor and risk level.",
+		},
+		[]string{"tenant", "country", "processor", "risk_level"},
+	)
+
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Route handler latency in seconds, by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	rateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Requests rejected by rate limiting, by route, client and the scope of the exhausted limit.",
+		},
+		[]string{"route", "client", "scope"},
+	)
+
+	transactionsIngested = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_ingested_total",
+			Help:      "Transaction outcomes reported by clients, by tenant, country, processor and status.",
+		},
+		[]string{"tenant", "country", "processor", "status"},
+	)
+
+	webhookDeadLettersDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "webhook_dead_letters_dropped_total",
+			Help:      "Dead-lettered webhook deliveries dropped to stay within the per-tenant cap, by tenant.",
+		},
+		[]string{"tenant"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(
+		routingDecisions,
+		requestDuration,
+		rateLimited,
+		transactionsIngested,
+		webhookDeadLettersDropped,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler returns the HTTP handler serving the metrics registry
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// RecordRoutingDecision counts a recorded routing decision
+func RecordRoutingDecision(tenant, country, processor, riskLevel string) {
+	routingDecisions.WithLabelValues(tenant, country, processor, riskLevel).Inc()
+}
+
+// RecordTransactionsIngested counts transaction outcomes reported by clients
+func RecordTransactionsIngested(transactions []models.Transaction) {
+	for _, tx := range transactions {
+		transactionsIngested.WithLabelValues(tx.Tenant, tx.Country, tx.Processor, tx.Status).Inc()
+	}
+}
+
+// RecordWebhookDeadLetterDropped counts a dead letter dropped because its tenant has too many
+func RecordWebhookDeadLetterDropped(tenant string) {
+	webhookDeadLettersDropped.WithLabelValues(tenant).Inc()
+}
+
+// ObserveRequest records the latency of a handled request
+// route is the registered route template, which keeps label cardinality bounded
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	requestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+// RecordRateLimited counts a request rejected by rate limiting
+// client is the authenticated client name, or "anonymous" for callers limited by IP address
+func RecordRateLimited(route, client, scope string) {
+	rateLimited.WithLabelValues(route, client, scope).Inc()
+}
+
+// RegisterRateLimitBuckets registers a gauge of the token buckets tracked by the rate limiter
+func RegisterRateLimitBuckets(count func() int) error {
+	return Registry.Register(prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_buckets",
+			Help:      "Token buckets tracked by the rate limiter, one per caller and limited route.",
+		},
+		func() float64 { return float64(count()) },
+	))
+}
+
+// ProcessorStatsSource provides the current processor health of a tenant, typically the routing service
+type ProcessorStatsSource interface {
+	GetAllProcessorStats() []models.ProcessorStats
+}
+
+// processorCollector exports per-processor gauges computed from the sources at scrape time
+type processorCollector struct {
+	sources      []ProcessorStatsSource // One per tenant
+	approvalRate *prometheus.Desc
+	transactions *prometheus.Desc
+	circuitState *prometheus.Desc
+}
+
+// RegisterProcessorCollector registers approval rate and circuit state gauges fed by the sources of every tenant
+func RegisterProcessorCollector(sources ...ProcessorStatsSource) error {
+	return Registry.Register(NewProcessorCollector(sources...))
+}
+
+// NewProcessorCollector creates a collector exporting approval rate and circuit state gauges fed by the sources
+// Gauges are labelled by tenant, so the same processor contracted by several tenants is exported once per tenant
+func NewProcessorCollector(sources ...ProcessorStatsSource) prometheus.Collector {
+	return &processorCollector{
+		sources: sources,
+		approvalRate: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "processor", "approval_rate_percent"),
+			"Approval rate of a processor over the routing time window.",
+			[]string{"tenant", "processor", "country"}, nil,
+		),
+		transactions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "processor", "window_transactions"),
+			"Transactions of a processor within the routing time window.",
+			[]string{"tenant", "processor", "country"}, nil,
+		),
+		circuitState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "processor", "circuit_state"),
+			"Circuit breaker state of a processor (1 for the current state, 0 otherwise).",
+			[]string{"tenant", "processor", "country", "state"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (pc *processorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pc.approvalRate
+	ch <- pc.transactions
+	ch <- pc.circuitState
+}
+
+// Collect implements prometheus.Collector
+func (pc *processorCollector) Collect(ch chan<- prometheus.Metric) {
+	states := []models.CircuitState{models.CircuitClosed, models.CircuitOpen, models.CircuitHalfOpen}
+
+	for _, source := range pc.sources {
+		for _, stat := range source.GetAllProcessorStats() {
+			ch <- prometheus.MustNewConstMetric(pc.approvalRate, prometheus.GaugeValue, stat.ApprovalRate, stat.Tenant, stat.Name, stat.Country)
+			ch <- prometheus.MustNewConstMetric(pc.transactions, prometheus.GaugeValue, float64(stat.TransactionCount), stat.Tenant, stat.Name, stat.Country)
+
+			// Closed circuits are omitted from processor stats
+			current := stat.CircuitState
+			if current == "" {
+				current = models.CircuitClosed
+			}
+
+			for _, state := range states {
+				value := 0.0
+				if state == current {
+					value = 1.0
+				}
+				ch <- prometheus.MustNewConstMetric(pc.circuitState, prometheus.GaugeValue, value, stat.Tenant, stat.Name, stat.Country, string(state))
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SnapshotRequest represents a request to snapshot the store to a file
+type SnapshotRequest struct {
+	Name      string `json:"name,omitempty"`      // File name in the snapshot directory; generated from the time when empty
+	Overwrite bool   `json:"overwrite,omitempty"` // Replace an existing snapshot of that name instead of failing
+}
+
+// SnapshotResponse describes a snapshot written or restored by the admin endpoints
+type SnapshotResponse struct {
+	Name             string    `json:"name"`
+	CreatedAt        time.Time `json:"created_at"`
+	Transactions     int       `json:"transactions"`
+	RoutingDecisions int       `json:"routing_decisions"`
+	CircuitEvents    int       `json:"circuit_events"`
+}
+
+// ClearStoreRequest represents a request to clear the store
+// Without a confirmation token the request only issues one; the store is cleared by repeating it with the token
+type ClearStoreRequest struct {
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+}
+
+// ClearStoreConfirmation is the token that must be sent back to clear the store
+type ClearStoreConfirmation struct {
+	Message           string    `json:"message"`
+	ConfirmationToken string    `json:"confirmation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// ClearStoreResponse represents the response after clearing the store
+type ClearStoreResponse struct {
+	Message                 string `json:"message"`
+	TransactionsCleared     int    `json:"transactions_cleared"`
+	RoutingDecisionsCleared int    `json:"routing_decisions_cleared"`
+}
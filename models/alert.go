@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Alert types
+const (
+	AlertApprovalRateDrop = "approval_rate_drop"
+)
+
+// Alert severities
+const (
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+// Alert statuses
+const (
+	AlertStatusActive   = "active"
+	AlertStatusResolved = "resolved"
+)
+
+// Alert represents a detected degradation of a processor
+type Alert struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Severity        string     `json:"severity"`
+	Status          string     `json:"status"`
+	Tenant          string     `json:"tenant"`
+	Processor       string     `json:"processor"`
+	Country         string     `json:"country"`
+	ShortRate       float64    `json:"short_rate"`       // Approval rate in the short window
+	BaselineRate    float64    `json:"baseline_rate"`    // Approval rate in the baseline window
+	ZScore          float64    `json:"z_score"`          // Two-proportion z-score of short versus baseline
+	ShortSamples    int        `json:"short_samples"`    // Transactions in the short window
+	BaselineSamples int        `json:"baseline_samples"` // Transactions in the baseline window
+	Message         string     `json:"message"`
+	StartedAt       time.Time  `json:"started_at"`
+	LastEvaluatedAt time.Time  `json:"last_evaluated_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AlertsResponse represents the response with alerts
+type AlertsResponse struct {
+	Alerts []Alert `json:"alerts"`
+}
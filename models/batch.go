@@ -0,0 +1,15 @@
+package models
+
+// BatchRoutingResult is the outcome of one request of a batch
+type BatchRoutingResult struct {
+	Index    int              `json:"index"` // Position of the request in the batch
+	Decision *RoutingResponse `json:"decision,omitempty"`
+	Error    *ErrorResponse   `json:"error,omitempty"`
+}
+
+// BatchRoutingResponse represents the response of a batch routing request, with results in request order
+type BatchRoutingResponse struct {
+	Results   []BatchRoutingResult `json:"results"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Circuit transition triggers
+const (
+	CircuitTriggerLowApprovalRate  = "approval_rate_below_threshold"
+	CircuitTriggerRecovered        = "approval_rate_recovered"
+	CircuitTriggerManualOverride   = "manual_override"
+	CircuitTriggerOverrideReleased = "override_released"
+	CircuitTriggerTimeout          = "breaker_timeout"
+	CircuitTriggerOverrideExpired  = "override_expired"
+)
+
+// CircuitEvent represents a single circuit breaker state transition
+type CircuitEvent struct {
+	Tenant       string       `json:"tenant"`
+	Processor    string       `json:"processor"`
+	Country      string       `json:"country"`
+	FromState    CircuitState `json:"from_state"`
+	ToState      CircuitState `json:"to_state"`
+	ApprovalRate float64      `json:"approval_rate"`
+	Trigger      string       `json:"trigger"`
+	Operator     string       `json:"operator,omitempty"` // Only set for manual transitions
+	Reason       string       `json:"reason,omitempty"`   // Only set for manual transitions
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+// CircuitHistoryResponse represents the response with circuit breaker transitions
+type CircuitHistoryResponse struct {
+	Events []CircuitEvent `json:"events"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// Country routing statuses
+const (
+	CountryStatusAvailable   = "available"
+	CountryStatusHighRisk    = "high_risk"
+	CountryStatusUnavailable = "unavailable"
+)
+
+// CountryStatusChange describes a change in whether and how well a country can be routed
+type CountryStatusChange struct {
+	Tenant       string  `json:"tenant"`
+	Country      string  `json:"country"`
+	FromStatus   string  `json:"from_status"`
+	ToStatus     string  `json:"to_status"`
+	Processor    string  `json:"processor,omitempty"` // Best processor, when one is available
+	ApprovalRate float64 `json:"approval_rate,omitempty"`
+	Reason       string  `json:"reason"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// TransactionOutcomeRequest represents the outcome of a payment reported back to the router
+type TransactionOutcomeRequest struct {
+	ID         string    `json:"id"`          // Generated when empty
+	DecisionID string    `json:"decision_id"` // Routing decision the payment followed, if any
+	Processor  string    `json:"processor"`   // Defaults to the decision's processor
+	Country    string    `json:"country" validate:"required,len=2"`
+	Currency   string    `json:"currency" validate:"required,len=3"`
+	Amount     float64   `json:"amount" validate:"required,gt=0"`
+	Status     string    `json:"status" validate:"required,oneof=approved declined"`
+	Timestamp  time.Time `json:"timestamp"` // Defaults to the time the outcome is received
+}
+
+// RoutingEffectiveness summarises how routed traffic performed against the router's predictions
+type RoutingEffectiveness struct {
+	Decisions             int     `json:"decisions"`               // Decisions made in the period
+	Outcomes              int     `json:"outcomes"`                // Decisions with an outcome on the routed processor
+	Approved              int     `json:"approved"`                // Approved outcomes on the routed processor
+	ApprovalRate          float64 `json:"approval_rate"`           // Realised approval rate of routed traffic
+	PredictedApprovalRate float64 `json:"predicted_approval_rate"` // Mean approval rate the router expected
+	BestAlternativeRate   float64 `json:"best_alternative_rate"`   // Mean rate of the runner-up candidate
+	Regret                float64 `json:"regret"`                  // Best alternative rate minus realised rate (percentage points)
+	FallbackUsed          int     `json:"fallback_used"`           // Decisions with an outcome on a different processor
+}
+
+// ProcessorEffectiveness is the routing effectiveness of traffic routed to one processor
+type ProcessorEffectiveness struct {
+	Processor string `json:"processor"`
+	Country   string `json:"country"`
+	RoutingEffectiveness
+}
+
+// RoutingEffectivenessResponse represents the response with routing effectiveness stats
+type RoutingEffectivenessResponse struct {
+	Overall    RoutingEffectiveness     `json:"overall"`
+	Processors []ProcessorEffectiveness `json:"processors"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // CircuitState represents the circuit breaker state
 type CircuitState string
 
@@ -11,13 +13,32 @@ const (
 
 // ProcessorStats represents the health statistics for a processor
 type ProcessorStats struct {
-	Name             string       `json:"name"`
-	Country          string       `json:"country"`
-	ApprovalRate     float64      `json:"approval_rate"`
-	TransactionCount int          `json:"transaction_count"`
-	LastUpdated      string       `json:"last_updated"`
-	CircuitState     CircuitState `json:"circuit_state,omitempty"`
-	CircuitOpenedAt  string       `json:"circuit_opened_at,omitempty"`
+	Name             string           `json:"name"`
+	Tenant           string           `json:"tenant"`
+	Country          string           `json:"country"`
+	ApprovalRate     float64          `json:"approval_rate"`
+	TransactionCount int              `json:"transaction_count"`
+	LastUpdated      string           `json:"last_updated"`
+	CircuitState     CircuitState     `json:"circuit_state,omitempty"`
+	CircuitOpenedAt  string           `json:"circuit_opened_at,omitempty"`
+	CircuitOverride  *CircuitOverride `json:"circuit_override,omitempty"`
+}
+
+// CircuitOverride describes a manual circuit breaker override set by an operator
+type CircuitOverride struct {
+	State     CircuitState `json:"state"`
+	Reason    string       `json:"reason"`
+	Operator  string       `json:"operator"`
+	CreatedAt string       `json:"created_at"`
+	ExpiresAt string       `json:"expires_at"`
+}
+
+// CircuitOverrideRequest represents a request to manually control a circuit breaker
+type CircuitOverrideRequest struct {
+	State     CircuitState `json:"state,omitempty"` // Only used when pinning an explicit state
+	Reason    string       `json:"reason" validate:"required"`
+	Operator  string       `json:"operator" validate:"required"`
+	ExpiresAt time.Time    `json:"expires_at" validate:"required"`
 }
 
 // ProcessorHealthResponse represents the response with all processor stats
@@ -41,3 +62,45 @@ type LoadDataResponse struct {
 	Message          string `json:"message"`
 	TransactionsLoaded int    `json:"transactions_loaded"`
 }
+
+// ProcessorTimeSeriesBucket holds the outcomes of a processor within one time bucket
+type ProcessorTimeSeriesBucket struct {
+	Start        time.Time    `json:"start"`
+	Approved     int          `json:"approved"`
+	Declined     int          `json:"declined"`
+	Total        int          `json:"total"`
+	ApprovalRate *float64     `json:"approval_rate"` // Null when the bucket has no transactions
+	CircuitState CircuitState `json:"circuit_state"` // State in effect at the end of the bucket
+}
+
+// CircuitStateInterval is a period during which a processor's circuit breaker was in one state
+type CircuitStateInterval struct {
+	State   CircuitState `json:"state"`
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	Trigger string       `json:"trigger,omitempty"` // Transition that started the interval; empty if it began before the range
+}
+
+// ProcessorTimeSeriesResponse represents a processor's approval rate over time with circuit state overlays
+type ProcessorTimeSeriesResponse struct {
+	Processor     string                      `json:"processor"`
+	Country       string                      `json:"country"`
+	From          time.Time                   `json:"from"`
+	To            time.Time                   `json:"to"`
+	Bucket        string                      `json:"bucket"`
+	Buckets       []ProcessorTimeSeriesBucket `json:"buckets"`
+	CircuitStates []CircuitStateInterval      `json:"circuit_states"`
+}
+
+// ErrorCatalogEntry documents one error code returned by the API
+type ErrorCatalogEntry struct {
+	Code        string `json:"code"`
+	HTTPStatus  int    `json:"http_status"`
+	GRPCCode    string `json:"grpc_code"`
+	Description string `json:"description"`
+}
+
+// ErrorCatalogResponse represents the response with every error code
+type ErrorCatalogResponse struct {
+	Errors []ErrorCatalogEntry `json:"errors"`
+}
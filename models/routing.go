@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // RoutingRequest represents a request to route a payment
 type RoutingRequest struct {
 	Amount   float64 `json:"amount" validate:"required,gt=0"`
@@ -15,26 +17,110 @@ type ProcessorOption struct {
 
 // RoutingResponse represents the response with processor selection
 type RoutingResponse struct {
-	Processor    string             `json:"processor"`
-	ApprovalRate float64            `json:"approval_rate"`
-	RiskLevel    string             `json:"risk_level"` // "low", "medium", "high"
-	Reason       string             `json:"reason"`
-	Timestamp    string             `json:"timestamp"`
-	Fallback     *ProcessorOption   `json:"fallback,omitempty"`     // Optional: Second best processor
-	LastResort   *ProcessorOption   `json:"last_resort,omitempty"`  // Optional: Third best processor
+	DecisionID   string           `json:"decision_id,omitempty"` // Not set in simulation mode
+	Processor    string           `json:"processor"`
+	ApprovalRate float64          `json:"approval_rate"`
+	RiskLevel    string           `json:"risk_level"` // "low", "medium", "high"
+	Reason       string           `json:"reason"`
+	Timestamp    string           `json:"timestamp"`
+	Fallback     *ProcessorOption `json:"fallback,omitempty"`    // Optional: Second best processor
+	LastResort   *ProcessorOption `json:"last_resort,omitempty"` // Optional: Third best processor
+
+	// SimulatedTransitions lists the circuit transitions a live request would have applied (simulation mode only)
+	SimulatedTransitions []CircuitEvent `json:"simulated_transitions,omitempty"`
+}
+
+// Routing strategies recorded on decisions
+const (
+	StrategyHighestApprovalRate = "highest_approval_rate"
+)
+
+// Reasons a processor was excluded from a routing decision
+const (
+	ExclusionCircuitOpen       = "circuit_open"
+	ExclusionCircuitForcedOpen = "circuit_forced_open"
+	ExclusionLowApprovalRate   = "approval_rate_below_threshold"
+)
+
+// CandidateScore is a processor that was ranked for a routing decision
+type CandidateScore struct {
+	Processor    string       `json:"processor"`
+	ApprovalRate float64      `json:"approval_rate"`
+	CircuitState CircuitState `json:"circuit_state"`
+}
+
+// ExcludedProcessor is a processor that was not ranked for a routing decision, with the reason why
+type ExcludedProcessor struct {
+	Processor    string       `json:"processor"`
+	Reason       string       `json:"reason"`
+	Detail       string       `json:"detail"`
+	ApprovalRate *float64     `json:"approval_rate,omitempty"` // Only set when the rate was calculated
+	CircuitState CircuitState `json:"circuit_state"`
 }
 
-// RoutingDecision represents a historical routing decision for tracking
+// RoutingDecision represents a historical routing decision for tracking and audit
 type RoutingDecision struct {
-	Processor    string  `json:"processor"`
-	Country      string  `json:"country"`
-	ApprovalRate float64 `json:"approval_rate"`
-	Timestamp    string  `json:"timestamp"`
+	ID            string              `json:"decision_id,omitempty"`
+	Tenant        string              `json:"tenant"`
+	Processor     string              `json:"processor"`
+	Country       string              `json:"country"`
+	ApprovalRate  float64             `json:"approval_rate"`
+	Timestamp     string              `json:"timestamp"`
+	Amount        float64             `json:"amount,omitempty"`
+	Currency      string              `json:"currency,omitempty"`
+	RiskLevel     string              `json:"risk_level,omitempty"`
+	Strategy      string              `json:"strategy,omitempty"`
+	ConfigVersion string              `json:"config_version,omitempty"`
+	Candidates    []CandidateScore    `json:"candidates,omitempty"` // Ranked best first
+	Excluded      []ExcludedProcessor `json:"excluded,omitempty"`
+}
+
+// RoutingDecisionsResponse represents a page of the routing decision audit log, newest first
+type RoutingDecisionsResponse struct {
+	Decisions  []RoutingDecision `json:"decisions"`
+	Total      int               `json:"total"` // Decisions matching the filters
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	NextOffset *int              `json:"next_offset,omitempty"` // Absent on the last page
+}
+
+// RoutingStatsQuery selects the decisions routing statistics are computed over
+type RoutingStatsQuery struct {
+	Country string
+	From    time.Time     // Zero for no lower bound
+	To      time.Time     // Zero for no upper bound
+	Limit   int           // Most recent decisions to include; zero for no limit
+	Bucket  time.Duration // Time-series bucket width; zero picks one from the time span
 }
 
 // RoutingStats represents the routing statistics
 type RoutingStats struct {
-	TotalDecisions int            `json:"total_decisions"`
+	Tenant         string         `json:"tenant"`
+	TotalDecisions int            `json:"total_decisions"` // All decisions ever recorded for the tenant
 	Distribution   map[string]int `json:"distribution"`
 	Window         string         `json:"window"`
+
+	Decisions       int                  `json:"decisions"`         // Decisions matching the query
+	From            *time.Time           `json:"from,omitempty"`    // Query lower bound, or the earliest decision included
+	To              *time.Time           `json:"to,omitempty"`      // Query upper bound, or the latest decision included
+	Share           map[string]float64   `json:"share"`             // Percentage of decisions per processor
+	RiskLevels      map[string]int       `json:"risk_levels"`       // Decisions per risk level
+	AvgApprovalRate map[string]float64   `json:"avg_approval_rate"` // Mean approval rate at decision time per processor
+	Fallback        FallbackUsage        `json:"fallback"`          // Based on outcomes linked by decision ID
+	Bucket          string               `json:"bucket,omitempty"`  // Width of each time-series bucket
+	TimeSeries      []RoutingStatsBucket `json:"time_series"`       // Oldest first, empty buckets included
+}
+
+// FallbackUsage counts decisions whose payment ended up on a processor other than the routed one
+type FallbackUsage struct {
+	DecisionsWithOutcome int     `json:"decisions_with_outcome"`
+	FallbackUsed         int     `json:"fallback_used"`
+	Rate                 float64 `json:"rate"` // Percentage of decisions with outcome
+}
+
+// RoutingStatsBucket is one time-series bucket of routing decisions
+type RoutingStatsBucket struct {
+	Start        time.Time      `json:"start"`
+	Decisions    int            `json:"decisions"`
+	Distribution map[string]int `json:"distribution"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Live stream message types, sent as the SSE event name
+// Router events (circuit.opened, country.high_risk, ...) are forwarded under their own type
+const (
+	StreamProcessorStats      = "processor_stats"
+	StreamRoutingDistribution = "routing_distribution"
+	StreamHeartbeat           = "heartbeat"
+)
+
+// RoutingDistributionUpdate is the routing distribution of a country's most recent decisions
+type RoutingDistributionUpdate struct {
+	Country      string             `json:"country"`
+	Decisions    int                `json:"decisions"`
+	Distribution map[string]int     `json:"distribution"`
+	Share        map[string]float64 `json:"share"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// StreamHeartbeatMessage is sent periodically so clients can detect a dead connection
+type StreamHeartbeatMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -5,12 +5,16 @@ import "time"
 // Transaction represents a payment transaction
 type Transaction struct {
 	ID        string    `json:"id"`
+	Tenant    string    `json:"tenant,omitempty"` // Business line; the default tenant when empty
 	Processor string    `json:"processor"`
 	Country   string    `json:"country"`
 	Currency  string    `json:"currency"`
 	Amount    float64   `json:"amount"`
 	Status    string    `json:"status"` // "approved" or "declined"
 	Timestamp time.Time `json:"timestamp"`
+
+	// DecisionID links the outcome to the routing decision that chose the processor
+	DecisionID string `json:"decision_id,omitempty"`
 }
 
 // IsApproved returns true if the transaction was approved
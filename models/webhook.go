@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Webhook delivery statuses
+const (
+	DeliveryPending      = "pending"
+	DeliveryDelivered    = "delivered"
+	DeliveryRetrying     = "retrying"
+	DeliveryDeadLettered = "dead_lettered"
+)
+
+// WebhookSubscriptionRequest represents a request to subscribe a URL to router events
+type WebhookSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret"`      // Generated when empty
+	EventTypes []string `json:"event_types"` // Empty subscribes to every event type
+}
+
+// WebhookSubscription is a URL that receives signed event notifications
+type WebhookSubscription struct {
+	ID         string    `json:"id"`
+	Tenant     string    `json:"tenant"` // Only events of this tenant are delivered
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"` // Only returned when the subscription is created
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDelivery records the attempts to deliver one event to one subscription
+type WebhookDelivery struct {
+	ID             string     `json:"id"`
+	Tenant         string     `json:"tenant"`
+	SubscriptionID string     `json:"subscription_id"`
+	URL            string     `json:"url"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"` // Status code of the last attempt
+	Error          string     `json:"error,omitempty"`           // Error of the last failed attempt
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+// WebhookSubscriptionsResponse represents the response with webhook subscriptions
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+// WebhookDeliveriesResponse represents the response with webhook deliveries
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
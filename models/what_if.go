@@ -0,0 +1,46 @@
+package models
+
+// RoutingConfigOverride holds hypothetical routing configuration values
+// Omitted fields keep the production value
+type RoutingConfigOverride struct {
+	TimeWindow              string   `json:"time_window,omitempty"` // Go duration, e.g. "30m"
+	HighRiskThreshold       *float64 `json:"high_risk_threshold,omitempty"`
+	MediumRiskThreshold     *float64 `json:"medium_risk_threshold,omitempty"`
+	CircuitBreakerThreshold *float64 `json:"circuit_breaker_threshold,omitempty"`
+	CircuitBreakerTimeout   string   `json:"circuit_breaker_timeout,omitempty"` // Go duration, e.g. "10m"
+}
+
+// WhatIfRequest represents a request to route against a hypothetical configuration
+// Either a single request or a batch of requests must be provided
+type WhatIfRequest struct {
+	Config   RoutingConfigOverride `json:"config"`
+	Request  *RoutingRequest       `json:"request,omitempty"`
+	Requests []RoutingRequest      `json:"requests,omitempty"`
+}
+
+// EffectiveRoutingConfig represents the routing configuration a what-if evaluation ran with
+type EffectiveRoutingConfig struct {
+	TimeWindow              string  `json:"time_window"`
+	HighRiskThreshold       float64 `json:"high_risk_threshold"`
+	MediumRiskThreshold     float64 `json:"medium_risk_threshold"`
+	CircuitBreakerThreshold float64 `json:"circuit_breaker_threshold"`
+	CircuitBreakerTimeout   string  `json:"circuit_breaker_timeout"`
+}
+
+// WhatIfResult represents the hypothetical outcome for a single routing request
+type WhatIfResult struct {
+	Request            RoutingRequest    `json:"request"`
+	Decision           *RoutingResponse  `json:"decision,omitempty"`
+	Rankings           []ProcessorOption `json:"rankings,omitempty"`            // All eligible processors, best first
+	CircuitTransitions []CircuitEvent    `json:"circuit_transitions,omitempty"` // Breaker transitions this request would trigger
+	Error              *ErrorResponse    `json:"error,omitempty"`
+
+	// Err is the routing error behind Error, for callers that map it to a more specific code
+	Err error `json:"-"`
+}
+
+// WhatIfResponse represents the response of a what-if evaluation
+type WhatIfResponse struct {
+	Config  EffectiveRoutingConfig `json:"config"`
+	Results []WhatIfResult         `json:"results"`
+}
@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"net/http"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Tags grouping the operations
+const (
+	tagSystem   = "system"
+	tagRouting  = "routing"
+	tagCircuits = "circuits"
+	tagAlerts   = "alerts"
+	tagWebhooks = "webhooks"
+	tagData     = "data"
+	tagAdmin    = "admin"
+)
+
+// errorResponse is the body of every error response
+var errorResponse = models.ErrorResponse{}
+
+// v1 is the path prefix of the versioned API group
+var v1 = "/" + constants.MicroserviceName + constants.V1
+
+// Query parameters shared by several operations
+var (
+	simulateParam = openapi3.NewQueryParameter("simulate").
+			WithDescription("Return the decision without recording it or changing circuit breaker state").
+			WithSchema(openapi3.NewBoolSchema())
+	failoverParam = openapi3.NewQueryParameter("failover").
+			WithDescription("Include the second and third best processors").
+			WithSchema(openapi3.NewBoolSchema())
+	countryParam = openapi3.NewQueryParameter("country").
+			WithDescription("ISO 3166-1 alpha-2 country code").
+			WithSchema(openapi3.NewStringSchema().WithLength(2))
+	processorParam = openapi3.NewQueryParameter("processor").
+			WithDescription("Processor name, e.g. RapidPay_BR").
+			WithSchema(openapi3.NewStringSchema())
+	fromParam = openapi3.NewQueryParameter("from").
+			WithDescription("RFC3339 lower bound").
+			WithSchema(openapi3.NewDateTimeSchema())
+	toParam = openapi3.NewQueryParameter("to").
+		WithDescription("RFC3339 upper bound").
+		WithSchema(openapi3.NewDateTimeSchema())
+	bucketParam = openapi3.NewQueryParameter("bucket").
+			WithDescription("Time-series bucket width as a Go duration, e.g. 5m or 1h").
+			WithSchema(openapi3.NewStringSchema())
+)
+
+// Operations returns every route of the HTTP API, in the order they are registered by routers.ConfigRouter
+func Operations() []Operation {
+	return []Operation{
+		{
+			Method: http.MethodGet, Path: constants.HealthCheck, ID: "healthCheck", Tag: tagSystem,
+			Summary: "Health check",
+			Status:  http.StatusOK, Response: models.HealthResponse{},
+			Public: true,
+		},
+		{
+			Method: http.MethodGet, Path: constants.Metrics, ID: "getMetrics", Tag: tagSystem,
+			Summary: "Prometheus metrics in the text exposition format",
+			Status:  http.StatusOK, ContentType: "text/plain",
+		},
+		{
+			Method: http.MethodGet, Path: constants.OpenAPI, ID: "getOpenAPI", Tag: tagSystem,
+			Summary: "This OpenAPI document",
+			Status:  http.StatusOK, Response: map[string]interface{}{},
+			Public: true,
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.Errors, ID: "listErrors", Tag: tagSystem,
+			Summary: "Every error code with its HTTP status and gRPC code",
+			Status:  http.StatusOK, Response: models.ErrorCatalogResponse{},
+			Public: true,
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.Route, ID: "routeTransaction", Tag: tagRouting,
+			Summary: "Route a payment to the best processor for its country",
+			Query:   []*openapi3.Parameter{simulateParam, failoverParam},
+			Body:    models.RoutingRequest{},
+			Status:  http.StatusOK, Response: models.RoutingResponse{},
+			Errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError, http.StatusServiceUnavailable},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.RouteWhatIf, ID: "routeWhatIf", Tag: tagRouting,
+			Summary: "Route requests against a hypothetical configuration without side effects",
+			Body:    models.WhatIfRequest{},
+			Status:  http.StatusOK, Response: models.WhatIfResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.RouteBatch, ID: "routeBatch", Tag: tagRouting,
+			Summary: "Route up to 1000 payments, with a result or an error per item",
+			Query:   []*openapi3.Parameter{simulateParam, failoverParam},
+			Body:    []models.RoutingRequest{},
+			Status:  http.StatusOK, Response: models.BatchRoutingResponse{},
+			Errors:             []int{http.StatusBadRequest},
+			SkipBodyValidation: true,
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.Processors, ID: "listProcessors", Tag: tagRouting,
+			Summary: "Health of every processor",
+			Status:  http.StatusOK, Response: models.ProcessorHealthResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.ProcessorByName, ID: "getProcessor", Tag: tagRouting,
+			Summary: "Health of one processor",
+			Status:  http.StatusOK, Response: models.ProcessorStats{},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.ProcessorStream, ID: "streamProcessorHealth", Tag: tagRouting,
+			Summary: "Server-Sent Events stream of processor stats, routing distribution and router events",
+			Query:   []*openapi3.Parameter{countryParam},
+			Status:  http.StatusOK, ContentType: "text/event-stream",
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.ProcessorTimeSeries, ID: "getProcessorTimeSeries", Tag: tagRouting,
+			Summary: "Approval rate of a processor over time with circuit state overlays",
+			Query:   []*openapi3.Parameter{fromParam, toParam, bucketParam},
+			Status:  http.StatusOK, Response: models.ProcessorTimeSeriesResponse{},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.RoutingStats, ID: "getRoutingStats", Tag: tagRouting,
+			Summary: "Routing distribution, risk levels and fallback usage",
+			Query: []*openapi3.Parameter{countryParam, fromParam, toParam,
+				openapi3.NewQueryParameter("limit").WithDescription("Most recent decisions to include").WithSchema(openapi3.NewIntegerSchema().WithMin(0)),
+				bucketParam},
+			Status: http.StatusOK, Response: models.RoutingStats{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.RoutingDecisions, ID: "listRoutingDecisions", Tag: tagRouting,
+			Summary: "Page of the routing decision audit log, newest first",
+			Query: []*openapi3.Parameter{processorParam, countryParam, fromParam, toParam,
+				openapi3.NewQueryParameter("offset").WithSchema(openapi3.NewIntegerSchema().WithMin(0)),
+				openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema().WithMin(1))},
+			Status: http.StatusOK, Response: models.RoutingDecisionsResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.RoutingDecision, ID: "getRoutingDecision", Tag: tagRouting,
+			Summary: "One routing decision with its candidates and exclusions",
+			Status:  http.StatusOK, Response: models.RoutingDecision{},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.RoutingEffectiveness, ID: "getRoutingEffectiveness", Tag: tagRouting,
+			Summary: "Realised approval rate of routed traffic against the router's predictions",
+			Query:   []*openapi3.Parameter{countryParam, fromParam, toParam},
+			Status:  http.StatusOK, Response: models.RoutingEffectivenessResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.CircuitOpen, ID: "openCircuit", Tag: tagCircuits,
+			Summary: "Force a processor's circuit open until the override expires",
+			Body:    models.CircuitOverrideRequest{},
+			Status:  http.StatusOK, Response: models.ProcessorStats{},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.CircuitClose, ID: "closeCircuit", Tag: tagCircuits,
+			Summary: "Force a processor's circuit closed until the override expires",
+			Body:    models.CircuitOverrideRequest{},
+			Status:  http.StatusOK, Response: models.ProcessorStats{},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.CircuitPin, ID: "pinCircuit", Tag: tagCircuits,
+			Summary: "Pin a processor's circuit to the state given in the body",
+			Body:    models.CircuitOverrideRequest{},
+			Status:  http.StatusOK, Response: models.ProcessorStats{},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodDelete, Path: v1 + constants.CircuitOverride, ID: "releaseCircuit", Tag: tagCircuits,
+			Summary: "Return a circuit to automatic control",
+			Status:  http.StatusOK, Response: models.ProcessorStats{},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.CircuitHistory, ID: "getCircuitHistory", Tag: tagCircuits,
+			Summary: "Circuit breaker transitions, oldest first",
+			Query:   []*openapi3.Parameter{processorParam, countryParam, fromParam, toParam},
+			Status:  http.StatusOK, Response: models.CircuitHistoryResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.Alerts, ID: "listAlerts", Tag: tagAlerts,
+			Summary: "Approval-rate degradation alerts",
+			Query: []*openapi3.Parameter{openapi3.NewQueryParameter("status").
+				WithDescription("Alert status; active when omitted").
+				WithSchema(openapi3.NewStringSchema().WithEnum(models.AlertStatusActive, models.AlertStatusResolved, "all"))},
+			Status: http.StatusOK, Response: models.AlertsResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.Webhooks, ID: "createWebhook", Tag: tagWebhooks,
+			Summary: "Subscribe a URL to router events; the secret is only returned here",
+			Body:    models.WebhookSubscriptionRequest{},
+			Status:  http.StatusCreated, Response: models.WebhookSubscription{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.Webhooks, ID: "listWebhooks", Tag: tagWebhooks,
+			Summary: "Webhook subscriptions",
+			Status:  http.StatusOK, Response: models.WebhookSubscriptionsResponse{},
+		},
+		{
+			Method: http.MethodDelete, Path: v1 + constants.Webhook, ID: "deleteWebhook", Tag: tagWebhooks,
+			Summary: "Remove a webhook subscription",
+			Status:  http.StatusNoContent,
+			Errors:  []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.WebhookDeliveries, ID: "listWebhookDeliveries", Tag: tagWebhooks,
+			Summary: "Webhook delivery log, newest first",
+			Query: []*openapi3.Parameter{
+				openapi3.NewQueryParameter("subscription_id").WithSchema(openapi3.NewStringSchema()),
+				openapi3.NewQueryParameter("status").WithSchema(openapi3.NewStringSchema().
+					WithEnum(models.DeliveryPending, models.DeliveryRetrying, models.DeliveryDelivered, models.DeliveryDeadLettered)),
+			},
+			Status: http.StatusOK, Response: models.WebhookDeliveriesResponse{},
+			Errors: []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodGet, Path: v1 + constants.WebhookDeadLetters, ID: "listWebhookDeadLetters", Tag: tagWebhooks,
+			Summary: "Deliveries that exhausted their retries",
+			Status:  http.StatusOK, Response: models.WebhookDeliveriesResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.WebhookDeadLetter, ID: "retryWebhookDeadLetter", Tag: tagWebhooks,
+			Summary: "Queue a dead-lettered delivery for another round of attempts",
+			Status:  http.StatusAccepted, Response: models.WebhookDelivery{},
+			Errors: []int{http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.Transactions, ID: "recordTransactionOutcome", Tag: tagData,
+			Summary: "Report the outcome of a payment",
+			Body:    models.TransactionOutcomeRequest{},
+			Status:  http.StatusCreated, Response: models.Transaction{},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.TransactionsLoad, ID: "loadTestData", Tag: tagData,
+			Summary: "Replace all transactions with the bundled test data (development only)",
+			Status:  http.StatusOK, Response: models.LoadDataResponse{},
+			Errors: []int{http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.AdminSnapshots, ID: "createSnapshot", Tag: tagAdmin,
+			Summary: "Write the full store to a file in the snapshot directory",
+			Body:    models.SnapshotRequest{},
+			Status:  http.StatusCreated, Response: models.SnapshotResponse{},
+			Errors: []int{http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.AdminSnapshotRestore, ID: "restoreSnapshot", Tag: tagAdmin,
+			Summary: "Replace the full store with a snapshot",
+			Status:  http.StatusOK, Response: models.SnapshotResponse{},
+			Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method: http.MethodPost, Path: v1 + constants.AdminStoreClear, ID: "clearStore", Tag: tagAdmin,
+			Summary: "Issue a confirmation token (202) or, with a valid token, clear the full store",
+			Body:    models.ClearStoreRequest{},
+			Status:  http.StatusOK, Response: models.ClearStoreResponse{},
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"voltarides/smart-router/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentPrefix is the reference prefix of schemas registered as components
+const componentPrefix = "#/components/schemas/"
+
+// enums lists the values of string types used as enumerations in the models
+var enums = map[reflect.Type][]interface{}{
+	reflect.TypeOf(models.CircuitClosed): {models.CircuitClosed, models.CircuitOpen, models.CircuitHalfOpen},
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaGenerator builds JSON schemas from Go types, registering named structs and enumerations as components
+// Field names come from json tags and constraints from validate tags, so the schemas follow the models
+type schemaGenerator struct {
+	schemas openapi3.Schemas
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{schemas: make(openapi3.Schemas)}
+}
+
+// ref returns the schema of a value's type
+func (g *schemaGenerator) ref(value interface{}) *openapi3.SchemaRef {
+	return g.schemaRef(reflect.TypeOf(value))
+}
+
+// schemaRef returns the schema of t
+func (g *schemaGenerator) schemaRef(t reflect.Type) *openapi3.SchemaRef {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return openapi3.NewSchemaRef("", openapi3.NewDateTimeSchema())
+	}
+	if values, ok := enums[t]; ok {
+		return g.component(t, func() *openapi3.Schema {
+			return openapi3.NewStringSchema().WithEnum(values...)
+		})
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.component(t, func() *openapi3.Schema {
+			schema := openapi3.NewObjectSchema()
+			g.addFields(schema, t)
+			return schema
+		})
+	case reflect.Slice, reflect.Array:
+		schema := openapi3.NewArraySchema()
+		schema.Items = g.schemaRef(t.Elem())
+		return openapi3.NewSchemaRef("", schema)
+	case reflect.Map:
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: g.schemaRef(t.Elem())}
+		return openapi3.NewSchemaRef("", schema)
+	case reflect.String:
+		return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+	case reflect.Bool:
+		return openapi3.NewSchemaRef("", openapi3.NewBoolSchema())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewSchemaRef("", openapi3.NewFloat64Schema())
+	default:
+		return openapi3.NewSchemaRef("", openapi3.NewSchema())
+	}
+}
+
+// component registers the schema of a named type once and returns a reference to it
+// Anonymous types are inlined
+func (g *schemaGenerator) component(t reflect.Type, build func() *openapi3.Schema) *openapi3.SchemaRef {
+	name := t.Name()
+	if name == "" {
+		return openapi3.NewSchemaRef("", build())
+	}
+
+	if existing, ok := g.schemas[name]; ok {
+		return openapi3.NewSchemaRef(componentPrefix+name, existing.Value)
+	}
+
+	schema := build()
+	g.schemas[name] = openapi3.NewSchemaRef("", schema)
+	return openapi3.NewSchemaRef(componentPrefix+name, schema)
+}
+
+// addFields adds the JSON fields of struct t as properties, flattening embedded structs
+func (g *schemaGenerator) addFields(schema *openapi3.Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			g.addFields(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schemaRef(field.Type)
+		if field.Type.Kind() == reflect.Ptr && property.Ref == "" {
+			// Pointers to scalars are nullable, e.g. the approval rate of an empty bucket
+			property.Value.Nullable = true
+		}
+
+		if applyValidation(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidation adds the constraints of a validate tag to a property and reports whether the field is required
+// Only the rules used by the models are translated
+func applyValidation(property *openapi3.SchemaRef, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			required = true
+		}
+
+		// Referenced schemas are shared, so constraints are only added to inline ones
+		if property.Ref != "" {
+			continue
+		}
+
+		schema := property.Value
+		switch name {
+		case "required":
+			if schema.Type.Is(openapi3.TypeString) && schema.MinLength == 0 {
+				schema.MinLength = 1
+			}
+		case "len":
+			length, _ := strconv.ParseUint(param, 10, 64)
+			schema.MinLength = length
+			schema.MaxLength = &length
+		case "gt":
+			min, _ := strconv.ParseFloat(param, 64)
+			schema.Min = &min
+			schema.ExclusiveMin = true
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "url":
+			schema.Format = "uri"
+		}
+	}
+
+	return required
+}
@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Version is the version of the API described by the document
+const Version = "1.0.0"
+
+// Operation describes one route of the HTTP API
+type Operation struct {
+	Method      string
+	Path        string // Echo route path, e.g. /volta-router/v1/processors/:name
+	ID          string
+	Summary     string
+	Tag         string
+	Query       []*openapi3.Parameter
+	Body        interface{} // Value of the request body type; nil when the route takes no body
+	Status      int         // Success status
+	Response    interface{} // Value of the success response type; nil for an empty or non-JSON response
+	ContentType string      // Success content type when not JSON
+	Errors      []int       // Error statuses, all answered with models.ErrorResponse
+	Public      bool        // Reachable without an API key or bearer token
+
+	// SkipBodyValidation leaves the body to the handler, for routes that report invalid items individually
+	SkipBodyValidation bool
+}
+
+// Security scheme names; every operation not marked Public accepts either
+const (
+	securityAPIKey = "apiKey"
+	securityBearer = "bearerAuth"
+)
+
+// security is the document-level requirement: an API key or a bearer token
+var security = *openapi3.NewSecurityRequirements().
+	With(openapi3.NewSecurityRequirement().Authenticate(securityAPIKey)).
+	With(openapi3.NewSecurityRequirement().Authenticate(securityBearer))
+
+var (
+	document     *openapi3.T
+	documentOnce sync.Once
+)
+
+// Document returns the OpenAPI 3 document of the HTTP API, built once from Operations
+func Document() *openapi3.T {
+	documentOnce.Do(func() {
+		document = build(Operations())
+	})
+	return document
+}
+
+// SpecPath converts an Echo route path to an OpenAPI path, e.g. /processors/:name to /processors/{name}
+func SpecPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParams returns the names of the parameters of an Echo route path, in order
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			names = append(names, segment[1:])
+		}
+	}
+	return names
+}
+
+// build generates the document from the operations, registering every model they use as a component schema
+func build(operations []Operation) *openapi3.T {
+	generator := newSchemaGenerator()
+	errorSchema := generator.ref(errorResponse)
+
+	paths := openapi3.NewPaths()
+	for _, op := range operations {
+		operation := openapi3.NewOperation()
+		operation.OperationID = op.ID
+		operation.Summary = op.Summary
+		operation.Tags = []string{op.Tag}
+
+		for _, name := range pathParams(op.Path) {
+			operation.AddParameter(openapi3.NewPathParameter(name).WithSchema(openapi3.NewStringSchema()))
+		}
+		for _, parameter := range op.Query {
+			operation.AddParameter(parameter)
+		}
+
+		if op.Body != nil {
+			operation.RequestBody = &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(generator.ref(op.Body)),
+			}
+		}
+
+		operation.Responses = openapi3.NewResponsesWithCapacity(len(op.Errors) + 1)
+		success := openapi3.NewResponse().WithDescription(http.StatusText(op.Status))
+		switch {
+		case op.ContentType != "":
+			success.WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{op.ContentType}))
+		case op.Response != nil:
+			success.WithJSONSchemaRef(generator.ref(op.Response))
+		}
+		operation.AddResponse(op.Status, success)
+
+		errorStatuses := op.Errors
+		if op.Public {
+			// Overrides the document-level requirement
+			operation.Security = openapi3.NewSecurityRequirements()
+		} else {
+			operation.AddParameter(openapi3.NewHeaderParameter("X-Tenant").
+				WithDescription("Tenant to act for instead of the client's own; admins only").
+				WithSchema(openapi3.NewStringSchema()))
+			errorStatuses = append([]int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests}, errorStatuses...)
+		}
+
+		for _, status := range errorStatuses {
+			operation.AddResponse(status, openapi3.NewResponse().WithDescription(http.StatusText(status)).WithJSONSchemaRef(errorSchema))
+		}
+
+		path := SpecPath(op.Path)
+		item := paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			paths.Set(path, item)
+		}
+		item.SetOperation(op.Method, operation)
+	}
+
+	securitySchemes := openapi3.SecuritySchemes{
+		securityAPIKey: &openapi3.SecuritySchemeRef{
+			Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-API-Key"),
+		},
+		securityBearer: &openapi3.SecuritySchemeRef{
+			Value: openapi3.NewJWTSecurityScheme(),
+		},
+	}
+
+	return &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:       "Volta Smart Router API",
+			Description: "Routes payments to the processor with the best recent approval rate in each country.",
+			Version:     Version,
+		},
+		Paths: paths,
+		Components: &openapi3.Components{
+			Schemas:         generator.schemas,
+			SecuritySchemes: securitySchemes,
+		},
+		Security: security,
+	}
+}
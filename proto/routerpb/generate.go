@@ -0,0 +1,4 @@
+// Package routerpb contains the generated protobuf and gRPC code for proto/router.proto
+package routerpb
+
+//go:generate protoc -I .. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative router.proto
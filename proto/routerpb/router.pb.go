@@ -0,0 +1,1533 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: router.proto
+
+package routerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Simulate      bool                   `protobuf:"varint,4,opt,name=simulate,proto3" json:"simulate,omitempty"` // Do not record the decision or change circuit state
+	Failover      bool                   `protobuf:"varint,5,opt,name=failover,proto3" json:"failover,omitempty"` // Include fallback and last resort processors
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteRequest) Reset() {
+	*x = RouteRequest{}
+	mi := &file_router_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteRequest) ProtoMessage() {}
+
+func (x *RouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteRequest.ProtoReflect.Descriptor instead.
+func (*RouteRequest) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RouteRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RouteRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *RouteRequest) GetSimulate() bool {
+	if x != nil {
+		return x.Simulate
+	}
+	return false
+}
+
+func (x *RouteRequest) GetFailover() bool {
+	if x != nil {
+		return x.Failover
+	}
+	return false
+}
+
+type ProcessorOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processor     string                 `protobuf:"bytes,1,opt,name=processor,proto3" json:"processor,omitempty"`
+	ApprovalRate  float64                `protobuf:"fixed64,2,opt,name=approval_rate,json=approvalRate,proto3" json:"approval_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessorOption) Reset() {
+	*x = ProcessorOption{}
+	mi := &file_router_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessorOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessorOption) ProtoMessage() {}
+
+func (x *ProcessorOption) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessorOption.ProtoReflect.Descriptor instead.
+func (*ProcessorOption) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProcessorOption) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *ProcessorOption) GetApprovalRate() float64 {
+	if x != nil {
+		return x.ApprovalRate
+	}
+	return 0
+}
+
+type CircuitEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processor     string                 `protobuf:"bytes,1,opt,name=processor,proto3" json:"processor,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	FromState     string                 `protobuf:"bytes,3,opt,name=from_state,json=fromState,proto3" json:"from_state,omitempty"`
+	ToState       string                 `protobuf:"bytes,4,opt,name=to_state,json=toState,proto3" json:"to_state,omitempty"`
+	ApprovalRate  float64                `protobuf:"fixed64,5,opt,name=approval_rate,json=approvalRate,proto3" json:"approval_rate,omitempty"`
+	Trigger       string                 `protobuf:"bytes,6,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Operator      string                 `protobuf:"bytes,7,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CircuitEvent) Reset() {
+	*x = CircuitEvent{}
+	mi := &file_router_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitEvent) ProtoMessage() {}
+
+func (x *CircuitEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitEvent.ProtoReflect.Descriptor instead.
+func (*CircuitEvent) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CircuitEvent) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetFromState() string {
+	if x != nil {
+		return x.FromState
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetToState() string {
+	if x != nil {
+		return x.ToState
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetApprovalRate() float64 {
+	if x != nil {
+		return x.ApprovalRate
+	}
+	return 0
+}
+
+func (x *CircuitEvent) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CircuitEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type RouteResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	DecisionId           string                 `protobuf:"bytes,1,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"` // Not set in simulation mode
+	Processor            string                 `protobuf:"bytes,2,opt,name=processor,proto3" json:"processor,omitempty"`
+	ApprovalRate         float64                `protobuf:"fixed64,3,opt,name=approval_rate,json=approvalRate,proto3" json:"approval_rate,omitempty"`
+	RiskLevel            string                 `protobuf:"bytes,4,opt,name=risk_level,json=riskLevel,proto3" json:"risk_level,omitempty"`
+	Reason               string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Timestamp            string                 `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // RFC 3339
+	Fallback             *ProcessorOption       `protobuf:"bytes,7,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	LastResort           *ProcessorOption       `protobuf:"bytes,8,opt,name=last_resort,json=lastResort,proto3" json:"last_resort,omitempty"`
+	SimulatedTransitions []*CircuitEvent        `protobuf:"bytes,9,rep,name=simulated_transitions,json=simulatedTransitions,proto3" json:"simulated_transitions,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RouteResponse) Reset() {
+	*x = RouteResponse{}
+	mi := &file_router_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteResponse) ProtoMessage() {}
+
+func (x *RouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteResponse.ProtoReflect.Descriptor instead.
+func (*RouteResponse) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RouteResponse) GetDecisionId() string {
+	if x != nil {
+		return x.DecisionId
+	}
+	return ""
+}
+
+func (x *RouteResponse) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *RouteResponse) GetApprovalRate() float64 {
+	if x != nil {
+		return x.ApprovalRate
+	}
+	return 0
+}
+
+func (x *RouteResponse) GetRiskLevel() string {
+	if x != nil {
+		return x.RiskLevel
+	}
+	return ""
+}
+
+func (x *RouteResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RouteResponse) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *RouteResponse) GetFallback() *ProcessorOption {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
+func (x *RouteResponse) GetLastResort() *ProcessorOption {
+	if x != nil {
+		return x.LastResort
+	}
+	return nil
+}
+
+func (x *RouteResponse) GetSimulatedTransitions() []*CircuitEvent {
+	if x != nil {
+		return x.SimulatedTransitions
+	}
+	return nil
+}
+
+type GetProcessorStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Single processor; empty for all
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"` // Only processors of this country; empty for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProcessorStatsRequest) Reset() {
+	*x = GetProcessorStatsRequest{}
+	mi := &file_router_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProcessorStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProcessorStatsRequest) ProtoMessage() {}
+
+func (x *GetProcessorStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProcessorStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetProcessorStatsRequest) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProcessorStatsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetProcessorStatsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type CircuitOverride struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CircuitOverride) Reset() {
+	*x = CircuitOverride{}
+	mi := &file_router_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitOverride) ProtoMessage() {}
+
+func (x *CircuitOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitOverride.ProtoReflect.Descriptor instead.
+func (*CircuitOverride) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CircuitOverride) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CircuitOverride) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CircuitOverride) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *CircuitOverride) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CircuitOverride) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ProcessorStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Country          string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	ApprovalRate     float64                `protobuf:"fixed64,3,opt,name=approval_rate,json=approvalRate,proto3" json:"approval_rate,omitempty"`
+	TransactionCount int32                  `protobuf:"varint,4,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	LastUpdated      string                 `protobuf:"bytes,5,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"` // RFC 3339
+	CircuitState     string                 `protobuf:"bytes,6,opt,name=circuit_state,json=circuitState,proto3" json:"circuit_state,omitempty"`
+	CircuitOpenedAt  string                 `protobuf:"bytes,7,opt,name=circuit_opened_at,json=circuitOpenedAt,proto3" json:"circuit_opened_at,omitempty"` // RFC 3339
+	CircuitOverride  *CircuitOverride       `protobuf:"bytes,8,opt,name=circuit_override,json=circuitOverride,proto3" json:"circuit_override,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProcessorStats) Reset() {
+	*x = ProcessorStats{}
+	mi := &file_router_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessorStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessorStats) ProtoMessage() {}
+
+func (x *ProcessorStats) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessorStats.ProtoReflect.Descriptor instead.
+func (*ProcessorStats) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProcessorStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProcessorStats) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ProcessorStats) GetApprovalRate() float64 {
+	if x != nil {
+		return x.ApprovalRate
+	}
+	return 0
+}
+
+func (x *ProcessorStats) GetTransactionCount() int32 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *ProcessorStats) GetLastUpdated() string {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return ""
+}
+
+func (x *ProcessorStats) GetCircuitState() string {
+	if x != nil {
+		return x.CircuitState
+	}
+	return ""
+}
+
+func (x *ProcessorStats) GetCircuitOpenedAt() string {
+	if x != nil {
+		return x.CircuitOpenedAt
+	}
+	return ""
+}
+
+func (x *ProcessorStats) GetCircuitOverride() *CircuitOverride {
+	if x != nil {
+		return x.CircuitOverride
+	}
+	return nil
+}
+
+type GetProcessorStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processors    []*ProcessorStats      `protobuf:"bytes,1,rep,name=processors,proto3" json:"processors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProcessorStatsResponse) Reset() {
+	*x = GetProcessorStatsResponse{}
+	mi := &file_router_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProcessorStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProcessorStatsResponse) ProtoMessage() {}
+
+func (x *GetProcessorStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProcessorStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetProcessorStatsResponse) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetProcessorStatsResponse) GetProcessors() []*ProcessorStats {
+	if x != nil {
+		return x.Processors
+	}
+	return nil
+}
+
+type GetRoutingStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Bucket        *durationpb.Duration   `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoutingStatsRequest) Reset() {
+	*x = GetRoutingStatsRequest{}
+	mi := &file_router_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoutingStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingStatsRequest) ProtoMessage() {}
+
+func (x *GetRoutingStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetRoutingStatsRequest) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetRoutingStatsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GetRoutingStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetRoutingStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetRoutingStatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRoutingStatsRequest) GetBucket() *durationpb.Duration {
+	if x != nil {
+		return x.Bucket
+	}
+	return nil
+}
+
+type FallbackUsage struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	DecisionsWithOutcome int32                  `protobuf:"varint,1,opt,name=decisions_with_outcome,json=decisionsWithOutcome,proto3" json:"decisions_with_outcome,omitempty"`
+	FallbackUsed         int32                  `protobuf:"varint,2,opt,name=fallback_used,json=fallbackUsed,proto3" json:"fallback_used,omitempty"`
+	Rate                 float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *FallbackUsage) Reset() {
+	*x = FallbackUsage{}
+	mi := &file_router_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FallbackUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FallbackUsage) ProtoMessage() {}
+
+func (x *FallbackUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FallbackUsage.ProtoReflect.Descriptor instead.
+func (*FallbackUsage) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FallbackUsage) GetDecisionsWithOutcome() int32 {
+	if x != nil {
+		return x.DecisionsWithOutcome
+	}
+	return 0
+}
+
+func (x *FallbackUsage) GetFallbackUsed() int32 {
+	if x != nil {
+		return x.FallbackUsed
+	}
+	return 0
+}
+
+func (x *FallbackUsage) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type RoutingStatsBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Decisions     int32                  `protobuf:"varint,2,opt,name=decisions,proto3" json:"decisions,omitempty"`
+	Distribution  map[string]int32       `protobuf:"bytes,3,rep,name=distribution,proto3" json:"distribution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutingStatsBucket) Reset() {
+	*x = RoutingStatsBucket{}
+	mi := &file_router_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingStatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingStatsBucket) ProtoMessage() {}
+
+func (x *RoutingStatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingStatsBucket.ProtoReflect.Descriptor instead.
+func (*RoutingStatsBucket) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RoutingStatsBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *RoutingStatsBucket) GetDecisions() int32 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *RoutingStatsBucket) GetDistribution() map[string]int32 {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+type RoutingStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalDecisions  int32                  `protobuf:"varint,1,opt,name=total_decisions,json=totalDecisions,proto3" json:"total_decisions,omitempty"`
+	Distribution    map[string]int32       `protobuf:"bytes,2,rep,name=distribution,proto3" json:"distribution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Window          string                 `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	Decisions       int32                  `protobuf:"varint,4,opt,name=decisions,proto3" json:"decisions,omitempty"`
+	From            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To              *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	Share           map[string]float64     `protobuf:"bytes,7,rep,name=share,proto3" json:"share,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	RiskLevels      map[string]int32       `protobuf:"bytes,8,rep,name=risk_levels,json=riskLevels,proto3" json:"risk_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	AvgApprovalRate map[string]float64     `protobuf:"bytes,9,rep,name=avg_approval_rate,json=avgApprovalRate,proto3" json:"avg_approval_rate,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Fallback        *FallbackUsage         `protobuf:"bytes,10,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	Bucket          string                 `protobuf:"bytes,11,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	TimeSeries      []*RoutingStatsBucket  `protobuf:"bytes,12,rep,name=time_series,json=timeSeries,proto3" json:"time_series,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoutingStats) Reset() {
+	*x = RoutingStats{}
+	mi := &file_router_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingStats) ProtoMessage() {}
+
+func (x *RoutingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingStats.ProtoReflect.Descriptor instead.
+func (*RoutingStats) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RoutingStats) GetTotalDecisions() int32 {
+	if x != nil {
+		return x.TotalDecisions
+	}
+	return 0
+}
+
+func (x *RoutingStats) GetDistribution() map[string]int32 {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *RoutingStats) GetDecisions() int32 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *RoutingStats) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetShare() map[string]float64 {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetRiskLevels() map[string]int32 {
+	if x != nil {
+		return x.RiskLevels
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetAvgApprovalRate() map[string]float64 {
+	if x != nil {
+		return x.AvgApprovalRate
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetFallback() *FallbackUsage {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
+func (x *RoutingStats) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *RoutingStats) GetTimeSeries() []*RoutingStatsBucket {
+	if x != nil {
+		return x.TimeSeries
+	}
+	return nil
+}
+
+type TransactionOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                   // Generated when empty
+	DecisionId    string                 `protobuf:"bytes,2,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"` // Routing decision the payment followed, if any
+	Processor     string                 `protobuf:"bytes,3,opt,name=processor,proto3" json:"processor,omitempty"`                     // Defaults to the decision's processor
+	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        float64                `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`       // "approved" or "declined"
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Defaults to the time the outcome is received
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionOutcome) Reset() {
+	*x = TransactionOutcome{}
+	mi := &file_router_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionOutcome) ProtoMessage() {}
+
+func (x *TransactionOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionOutcome.ProtoReflect.Descriptor instead.
+func (*TransactionOutcome) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TransactionOutcome) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetDecisionId() string {
+	if x != nil {
+		return x.DecisionId
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransactionOutcome) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransactionOutcome) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Processor     string                 `protobuf:"bytes,2,opt,name=processor,proto3" json:"processor,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	DecisionId    string                 `protobuf:"bytes,8,opt,name=decision_id,json=decisionId,proto3" json:"decision_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_router_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetProcessor() string {
+	if x != nil {
+		return x.Processor
+	}
+	return ""
+}
+
+func (x *Transaction) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transaction) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Transaction) GetDecisionId() string {
+	if x != nil {
+		return x.DecisionId
+	}
+	return ""
+}
+
+type IngestError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the outcome in the stream
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestError) Reset() {
+	*x = IngestError{}
+	mi := &file_router_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestError) ProtoMessage() {}
+
+func (x *IngestError) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestError.ProtoReflect.Descriptor instead.
+func (*IngestError) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IngestError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *IngestError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type IngestTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      int32                  `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	Rejected      int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Errors        []*IngestError         `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestTransactionsResponse) Reset() {
+	*x = IngestTransactionsResponse{}
+	mi := &file_router_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestTransactionsResponse) ProtoMessage() {}
+
+func (x *IngestTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*IngestTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_router_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IngestTransactionsResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *IngestTransactionsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestTransactionsResponse) GetErrors() []*IngestError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_router_proto protoreflect.FileDescriptor
+
+const file_router_proto_rawDesc = "" +
+	"\n" +
+	"\frouter.proto\x12\x0fvolta.router.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x94\x01\n" +
+	"\fRouteRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\bsimulate\x18\x04 \x01(\bR\bsimulate\x12\x1a\n" +
+	"\bfailover\x18\x05 \x01(\bR\bfailover\"T\n" +
+	"\x0fProcessorOption\x12\x1c\n" +
+	"\tprocessor\x18\x01 \x01(\tR\tprocessor\x12#\n" +
+	"\rapproval_rate\x18\x02 \x01(\x01R\fapprovalRate\"\xad\x02\n" +
+	"\fCircuitEvent\x12\x1c\n" +
+	"\tprocessor\x18\x01 \x01(\tR\tprocessor\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x1d\n" +
+	"\n" +
+	"from_state\x18\x03 \x01(\tR\tfromState\x12\x19\n" +
+	"\bto_state\x18\x04 \x01(\tR\atoState\x12#\n" +
+	"\rapproval_rate\x18\x05 \x01(\x01R\fapprovalRate\x12\x18\n" +
+	"\atrigger\x18\x06 \x01(\tR\atrigger\x12\x1a\n" +
+	"\boperator\x18\a \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x128\n" +
+	"\ttimestamp\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x9d\x03\n" +
+	"\rRouteResponse\x12\x1f\n" +
+	"\vdecision_id\x18\x01 \x01(\tR\n" +
+	"decisionId\x12\x1c\n" +
+	"\tprocessor\x18\x02 \x01(\tR\tprocessor\x12#\n" +
+	"\rapproval_rate\x18\x03 \x01(\x01R\fapprovalRate\x12\x1d\n" +
+	"\n" +
+	"risk_level\x18\x04 \x01(\tR\triskLevel\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\tR\ttimestamp\x12<\n" +
+	"\bfallback\x18\a \x01(\v2 .volta.router.v1.ProcessorOptionR\bfallback\x12A\n" +
+	"\vlast_resort\x18\b \x01(\v2 .volta.router.v1.ProcessorOptionR\n" +
+	"lastResort\x12R\n" +
+	"\x15simulated_transitions\x18\t \x03(\v2\x1d.volta.router.v1.CircuitEventR\x14simulatedTransitions\"H\n" +
+	"\x18GetProcessorStatsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\"\x99\x01\n" +
+	"\x0fCircuitOverride\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"\xd1\x02\n" +
+	"\x0eProcessorStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12#\n" +
+	"\rapproval_rate\x18\x03 \x01(\x01R\fapprovalRate\x12+\n" +
+	"\x11transaction_count\x18\x04 \x01(\x05R\x10transactionCount\x12!\n" +
+	"\flast_updated\x18\x05 \x01(\tR\vlastUpdated\x12#\n" +
+	"\rcircuit_state\x18\x06 \x01(\tR\fcircuitState\x12*\n" +
+	"\x11circuit_opened_at\x18\a \x01(\tR\x0fcircuitOpenedAt\x12K\n" +
+	"\x10circuit_override\x18\b \x01(\v2 .volta.router.v1.CircuitOverrideR\x0fcircuitOverride\"\\\n" +
+	"\x19GetProcessorStatsResponse\x12?\n" +
+	"\n" +
+	"processors\x18\x01 \x03(\v2\x1f.volta.router.v1.ProcessorStatsR\n" +
+	"processors\"\xd7\x01\n" +
+	"\x16GetRoutingStatsRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x121\n" +
+	"\x06bucket\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06bucket\"~\n" +
+	"\rFallbackUsage\x124\n" +
+	"\x16decisions_with_outcome\x18\x01 \x01(\x05R\x14decisionsWithOutcome\x12#\n" +
+	"\rfallback_used\x18\x02 \x01(\x05R\ffallbackUsed\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\"\x80\x02\n" +
+	"\x12RoutingStatsBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x1c\n" +
+	"\tdecisions\x18\x02 \x01(\x05R\tdecisions\x12Y\n" +
+	"\fdistribution\x18\x03 \x03(\v25.volta.router.v1.RoutingStatsBucket.DistributionEntryR\fdistribution\x1a?\n" +
+	"\x11DistributionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xa6\a\n" +
+	"\fRoutingStats\x12'\n" +
+	"\x0ftotal_decisions\x18\x01 \x01(\x05R\x0etotalDecisions\x12S\n" +
+	"\fdistribution\x18\x02 \x03(\v2/.volta.router.v1.RoutingStats.DistributionEntryR\fdistribution\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\tR\x06window\x12\x1c\n" +
+	"\tdecisions\x18\x04 \x01(\x05R\tdecisions\x12.\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12>\n" +
+	"\x05share\x18\a \x03(\v2(.volta.router.v1.RoutingStats.ShareEntryR\x05share\x12N\n" +
+	"\vrisk_levels\x18\b \x03(\v2-.volta.router.v1.RoutingStats.RiskLevelsEntryR\n" +
+	"riskLevels\x12^\n" +
+	"\x11avg_approval_rate\x18\t \x03(\v22.volta.router.v1.RoutingStats.AvgApprovalRateEntryR\x0favgApprovalRate\x12:\n" +
+	"\bfallback\x18\n" +
+	" \x01(\v2\x1e.volta.router.v1.FallbackUsageR\bfallback\x12\x16\n" +
+	"\x06bucket\x18\v \x01(\tR\x06bucket\x12D\n" +
+	"\vtime_series\x18\f \x03(\v2#.volta.router.v1.RoutingStatsBucketR\n" +
+	"timeSeries\x1a?\n" +
+	"\x11DistributionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"ShareEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a=\n" +
+	"\x0fRiskLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aB\n" +
+	"\x14AvgApprovalRateEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x83\x02\n" +
+	"\x12TransactionOutcome\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdecision_id\x18\x02 \x01(\tR\n" +
+	"decisionId\x12\x1c\n" +
+	"\tprocessor\x18\x03 \x01(\tR\tprocessor\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x128\n" +
+	"\ttimestamp\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xfc\x01\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tprocessor\x18\x02 \x01(\tR\tprocessor\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1f\n" +
+	"\vdecision_id\x18\b \x01(\tR\n" +
+	"decisionId\"Q\n" +
+	"\vIngestError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8a\x01\n" +
+	"\x1aIngestTransactionsResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected\x124\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1c.volta.router.v1.IngestErrorR\x06errors2\xe0\x03\n" +
+	"\rRouterService\x12F\n" +
+	"\x05Route\x12\x1d.volta.router.v1.RouteRequest\x1a\x1e.volta.router.v1.RouteResponse\x12j\n" +
+	"\x11GetProcessorStats\x12).volta.router.v1.GetProcessorStatsRequest\x1a*.volta.router.v1.GetProcessorStatsResponse\x12Y\n" +
+	"\x0fGetRoutingStats\x12'.volta.router.v1.GetRoutingStatsRequest\x1a\x1d.volta.router.v1.RoutingStats\x12V\n" +
+	"\x11RecordTransaction\x12#.volta.router.v1.TransactionOutcome\x1a\x1c.volta.router.v1.Transaction\x12h\n" +
+	"\x12IngestTransactions\x12#.volta.router.v1.TransactionOutcome\x1a+.volta.router.v1.IngestTransactionsResponse(\x01B(Z&voltarides/smart-router/proto/routerpbb\x06proto3"
+
+var (
+	file_router_proto_rawDescOnce sync.Once
+	file_router_proto_rawDescData []byte
+)
+
+func file_router_proto_rawDescGZIP() []byte {
+	file_router_proto_rawDescOnce.Do(func() {
+		file_router_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_router_proto_rawDesc), len(file_router_proto_rawDesc)))
+	})
+	return file_router_proto_rawDescData
+}
+
+var file_router_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_router_proto_goTypes = []any{
+	(*RouteRequest)(nil),               // 0: volta.router.v1.RouteRequest
+	(*ProcessorOption)(nil),            // 1: volta.router.v1.ProcessorOption
+	(*CircuitEvent)(nil),               // 2: volta.router.v1.CircuitEvent
+	(*RouteResponse)(nil),              // 3: volta.router.v1.RouteResponse
+	(*GetProcessorStatsRequest)(nil),   // 4: volta.router.v1.GetProcessorStatsRequest
+	(*CircuitOverride)(nil),            // 5: volta.router.v1.CircuitOverride
+	(*ProcessorStats)(nil),             // 6: volta.router.v1.ProcessorStats
+	(*GetProcessorStatsResponse)(nil),  // 7: volta.router.v1.GetProcessorStatsResponse
+	(*GetRoutingStatsRequest)(nil),     // 8: volta.router.v1.GetRoutingStatsRequest
+	(*FallbackUsage)(nil),              // 9: volta.router.v1.FallbackUsage
+	(*RoutingStatsBucket)(nil),         // 10: volta.router.v1.RoutingStatsBucket
+	(*RoutingStats)(nil),               // 11: volta.router.v1.RoutingStats
+	(*TransactionOutcome)(nil),         // 12: volta.router.v1.TransactionOutcome
+	(*Transaction)(nil),                // 13: volta.router.v1.Transaction
+	(*IngestError)(nil),                // 14: volta.router.v1.IngestError
+	(*IngestTransactionsResponse)(nil), // 15: volta.router.v1.IngestTransactionsResponse
+	nil,                                // 16: volta.router.v1.RoutingStatsBucket.DistributionEntry
+	nil,                                // 17: volta.router.v1.RoutingStats.DistributionEntry
+	nil,                                // 18: volta.router.v1.RoutingStats.ShareEntry
+	nil,                                // 19: volta.router.v1.RoutingStats.RiskLevelsEntry
+	nil,                                // 20: volta.router.v1.RoutingStats.AvgApprovalRateEntry
+	(*timestamppb.Timestamp)(nil),      // 21: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 22: google.protobuf.Duration
+}
+var file_router_proto_depIdxs = []int32{
+	21, // 0: volta.router.v1.CircuitEvent.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 1: volta.router.v1.RouteResponse.fallback:type_name -> volta.router.v1.ProcessorOption
+	1,  // 2: volta.router.v1.RouteResponse.last_resort:type_name -> volta.router.v1.ProcessorOption
+	2,  // 3: volta.router.v1.RouteResponse.simulated_transitions:type_name -> volta.router.v1.CircuitEvent
+	5,  // 4: volta.router.v1.ProcessorStats.circuit_override:type_name -> volta.router.v1.CircuitOverride
+	6,  // 5: volta.router.v1.GetProcessorStatsResponse.processors:type_name -> volta.router.v1.ProcessorStats
+	21, // 6: volta.router.v1.GetRoutingStatsRequest.from:type_name -> google.protobuf.Timestamp
+	21, // 7: volta.router.v1.GetRoutingStatsRequest.to:type_name -> google.protobuf.Timestamp
+	22, // 8: volta.router.v1.GetRoutingStatsRequest.bucket:type_name -> google.protobuf.Duration
+	21, // 9: volta.router.v1.RoutingStatsBucket.start:type_name -> google.protobuf.Timestamp
+	16, // 10: volta.router.v1.RoutingStatsBucket.distribution:type_name -> volta.router.v1.RoutingStatsBucket.DistributionEntry
+	17, // 11: volta.router.v1.RoutingStats.distribution:type_name -> volta.router.v1.RoutingStats.DistributionEntry
+	21, // 12: volta.router.v1.RoutingStats.from:type_name -> google.protobuf.Timestamp
+	21, // 13: volta.router.v1.RoutingStats.to:type_name -> google.protobuf.Timestamp
+	18, // 14: volta.router.v1.RoutingStats.share:type_name -> volta.router.v1.RoutingStats.ShareEntry
+	19, // 15: volta.router.v1.RoutingStats.risk_levels:type_name -> volta.router.v1.RoutingStats.RiskLevelsEntry
+	20, // 16: volta.router.v1.RoutingStats.avg_approval_rate:type_name -> volta.router.v1.RoutingStats.AvgApprovalRateEntry
+	9,  // 17: volta.router.v1.RoutingStats.fallback:type_name -> volta.router.v1.FallbackUsage
+	10, // 18: volta.router.v1.RoutingStats.time_series:type_name -> volta.router.v1.RoutingStatsBucket
+	21, // 19: volta.router.v1.TransactionOutcome.timestamp:type_name -> google.protobuf.Timestamp
+	21, // 20: volta.router.v1.Transaction.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 21: volta.router.v1.IngestTransactionsResponse.errors:type_name -> volta.router.v1.IngestError
+	0,  // 22: volta.router.v1.RouterService.Route:input_type -> volta.router.v1.RouteRequest
+	4,  // 23: volta.router.v1.RouterService.GetProcessorStats:input_type -> volta.router.v1.GetProcessorStatsRequest
+	8,  // 24: volta.router.v1.RouterService.GetRoutingStats:input_type -> volta.router.v1.GetRoutingStatsRequest
+	12, // 25: volta.router.v1.RouterService.RecordTransaction:input_type -> volta.router.v1.TransactionOutcome
+	12, // 26: volta.router.v1.RouterService.IngestTransactions:input_type -> volta.router.v1.TransactionOutcome
+	3,  // 27: volta.router.v1.RouterService.Route:output_type -> volta.router.v1.RouteResponse
+	7,  // 28: volta.router.v1.RouterService.GetProcessorStats:output_type -> volta.router.v1.GetProcessorStatsResponse
+	11, // 29: volta.router.v1.RouterService.GetRoutingStats:output_type -> volta.router.v1.RoutingStats
+	13, // 30: volta.router.v1.RouterService.RecordTransaction:output_type -> volta.router.v1.Transaction
+	15, // 31: volta.router.v1.RouterService.IngestTransactions:output_type -> volta.router.v1.IngestTransactionsResponse
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_router_proto_init() }
+func file_router_proto_init() {
+	if File_router_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_router_proto_rawDesc), len(file_router_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_router_proto_goTypes,
+		DependencyIndexes: file_router_proto_depIdxs,
+		MessageInfos:      file_router_proto_msgTypes,
+	}.Build()
+	File_router_proto = out.File
+	file_router_proto_goTypes = nil
+	file_router_proto_depIdxs = nil
+}
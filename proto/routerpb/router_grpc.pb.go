@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: router.proto
+
+package routerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RouterService_Route_FullMethodName              = "/volta.router.v1.RouterService/Route"
+	RouterService_GetProcessorStats_FullMethodName  = "/volta.router.v1.RouterService/GetProcessorStats"
+	RouterService_GetRoutingStats_FullMethodName    = "/volta.router.v1.RouterService/GetRoutingStats"
+	RouterService_RecordTransaction_FullMethodName  = "/volta.router.v1.RouterService/RecordTransaction"
+	RouterService_IngestTransactions_FullMethodName = "/volta.router.v1.RouterService/IngestTransactions"
+)
+
+// RouterServiceClient is the client API for RouterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RouterService exposes the smart router over gRPC, backed by the same RoutingService as the HTTP API.
+// Errors carry the HTTP API's error code (e.g. "unsupported_country") as the status message prefix.
+type RouterServiceClient interface {
+	// Route selects the best processor for a transaction (POST /route).
+	Route(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteResponse, error)
+	// GetProcessorStats returns processor health (GET /processors, GET /processors/:name).
+	GetProcessorStats(ctx context.Context, in *GetProcessorStatsRequest, opts ...grpc.CallOption) (*GetProcessorStatsResponse, error)
+	// GetRoutingStats returns routing decision statistics (GET /routing/stats).
+	GetRoutingStats(ctx context.Context, in *GetRoutingStatsRequest, opts ...grpc.CallOption) (*RoutingStats, error)
+	// RecordTransaction reports the outcome of a payment (POST /transactions).
+	RecordTransaction(ctx context.Context, in *TransactionOutcome, opts ...grpc.CallOption) (*Transaction, error)
+	// IngestTransactions records a stream of payment outcomes; invalid outcomes are reported, not fatal.
+	IngestTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TransactionOutcome, IngestTransactionsResponse], error)
+}
+
+type routerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRouterServiceClient(cc grpc.ClientConnInterface) RouterServiceClient {
+	return &routerServiceClient{cc}
+}
+
+func (c *routerServiceClient) Route(ctx context.Context, in *RouteRequest, opts ...grpc.CallOption) (*RouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RouteResponse)
+	err := c.cc.Invoke(ctx, RouterService_Route_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerServiceClient) GetProcessorStats(ctx context.Context, in *GetProcessorStatsRequest, opts ...grpc.CallOption) (*GetProcessorStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProcessorStatsResponse)
+	err := c.cc.Invoke(ctx, RouterService_GetProcessorStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerServiceClient) GetRoutingStats(ctx context.Context, in *GetRoutingStatsRequest, opts ...grpc.CallOption) (*RoutingStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoutingStats)
+	err := c.cc.Invoke(ctx, RouterService_GetRoutingStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerServiceClient) RecordTransaction(ctx context.Context, in *TransactionOutcome, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, RouterService_RecordTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerServiceClient) IngestTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TransactionOutcome, IngestTransactionsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RouterService_ServiceDesc.Streams[0], RouterService_IngestTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TransactionOutcome, IngestTransactionsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouterService_IngestTransactionsClient = grpc.ClientStreamingClient[TransactionOutcome, IngestTransactionsResponse]
+
+// RouterServiceServer is the server API for RouterService service.
+// All implementations must embed UnimplementedRouterServiceServer
+// for forward compatibility.
+//
+// RouterService exposes the smart router over gRPC, backed by the same RoutingService as the HTTP API.
+// Errors carry the HTTP API's error code (e.g. "unsupported_country") as the status message prefix.
+type RouterServiceServer interface {
+	// Route selects the best processor for a transaction (POST /route).
+	Route(context.Context, *RouteRequest) (*RouteResponse, error)
+	// GetProcessorStats returns processor health (GET /processors, GET /processors/:name).
+	GetProcessorStats(context.Context, *GetProcessorStatsRequest) (*GetProcessorStatsResponse, error)
+	// GetRoutingStats returns routing decision statistics (GET /routing/stats).
+	GetRoutingStats(context.Context, *GetRoutingStatsRequest) (*RoutingStats, error)
+	// RecordTransaction reports the outcome of a payment (POST /transactions).
+	RecordTransaction(context.Context, *TransactionOutcome) (*Transaction, error)
+	// IngestTransactions records a stream of payment outcomes; invalid outcomes are reported, not fatal.
+	IngestTransactions(grpc.ClientStreamingServer[TransactionOutcome, IngestTransactionsResponse]) error
+	mustEmbedUnimplementedRouterServiceServer()
+}
+
+// UnimplementedRouterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRouterServiceServer struct{}
+
+func (UnimplementedRouterServiceServer) Route(context.Context, *RouteRequest) (*RouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Route not implemented")
+}
+func (UnimplementedRouterServiceServer) GetProcessorStats(context.Context, *GetProcessorStatsRequest) (*GetProcessorStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcessorStats not implemented")
+}
+func (UnimplementedRouterServiceServer) GetRoutingStats(context.Context, *GetRoutingStatsRequest) (*RoutingStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingStats not implemented")
+}
+func (UnimplementedRouterServiceServer) RecordTransaction(context.Context, *TransactionOutcome) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordTransaction not implemented")
+}
+func (UnimplementedRouterServiceServer) IngestTransactions(grpc.ClientStreamingServer[TransactionOutcome, IngestTransactionsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestTransactions not implemented")
+}
+func (UnimplementedRouterServiceServer) mustEmbedUnimplementedRouterServiceServer() {}
+func (UnimplementedRouterServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRouterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RouterServiceServer will
+// result in compilation errors.
+type UnsafeRouterServiceServer interface {
+	mustEmbedUnimplementedRouterServiceServer()
+}
+
+func RegisterRouterServiceServer(s grpc.ServiceRegistrar, srv RouterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRouterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RouterService_ServiceDesc, srv)
+}
+
+func _RouterService_Route_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServiceServer).Route(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterService_Route_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServiceServer).Route(ctx, req.(*RouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterService_GetProcessorStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessorStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServiceServer).GetProcessorStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterService_GetProcessorStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServiceServer).GetProcessorStats(ctx, req.(*GetProcessorStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterService_GetRoutingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutingStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServiceServer).GetRoutingStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterService_GetRoutingStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServiceServer).GetRoutingStats(ctx, req.(*GetRoutingStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterService_RecordTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionOutcome)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServiceServer).RecordTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterService_RecordTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServiceServer).RecordTransaction(ctx, req.(*TransactionOutcome))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterService_IngestTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RouterServiceServer).IngestTransactions(&grpc.GenericServerStream[TransactionOutcome, IngestTransactionsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouterService_IngestTransactionsServer = grpc.ClientStreamingServer[TransactionOutcome, IngestTransactionsResponse]
+
+// RouterService_ServiceDesc is the grpc.ServiceDesc for RouterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RouterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "volta.router.v1.RouterService",
+	HandlerType: (*RouterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Route",
+			Handler:    _RouterService_Route_Handler,
+		},
+		{
+			MethodName: "GetProcessorStats",
+			Handler:    _RouterService_GetProcessorStats_Handler,
+		},
+		{
+			MethodName: "GetRoutingStats",
+			Handler:    _RouterService_GetRoutingStats_Handler,
+		},
+		{
+			MethodName: "RecordTransaction",
+			Handler:    _RouterService_RecordTransaction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestTransactions",
+			Handler:       _RouterService_IngestTransactions_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "router.proto",
+}
@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+)
+
+// Scopes of the bucket that rejected a request
+const (
+	ScopeClient = "client" // The caller's quota across all routes
+	ScopeRoute  = "route"  // The caller's limit on one route
+)
+
+// sweepInterval is how often buckets idle long enough to be full again are dropped
+const sweepInterval = time.Minute
+
+// Decision is the outcome of a rate limit check
+type Decision struct {
+	Allowed    bool
+	Scope      string // Bucket that rejected the request; empty when allowed
+	Limit      config.RateLimit
+	RetryAfter time.Duration // Wait until the rejecting bucket holds a token again
+}
+
+// RetryAfterSeconds returns RetryAfter rounded up to whole seconds, as sent in the Retry-After header
+func (d Decision) RetryAfterSeconds() int {
+	seconds := int(math.Ceil(d.RetryAfter.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// bucket is a token bucket; tokens are refilled lazily when it is checked
+type bucket struct {
+	limit  config.RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accrued since the last check
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.last = now
+	}
+}
+
+// wait returns how long until the bucket holds a whole token
+func (b *bucket) wait() time.Duration {
+	missing := 1 - b.tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / b.limit.Rate * float64(time.Second))
+}
+
+// Limiter applies per-caller token buckets: one quota across all routes and one per limited route
+// A request takes a token from each of its buckets, and only when all of them have one
+type Limiter struct {
+	enabled bool
+	def     config.RateLimit
+	clients map[string]config.RateLimit
+	routes  map[string]config.RateLimit
+
+	buckets   map[string]*bucket // key: scope, caller and, for route buckets, route
+	lastSweep time.Time
+	mu        sync.Mutex
+
+	clock clock.Clock
+}
+
+// LimiterOption configures a Limiter
+type LimiterOption func(*Limiter)
+
+// WithClock sets the clock used to refill buckets
+func WithClock(clk clock.Clock) LimiterOption {
+	return func(l *Limiter) {
+		l.clock = clk
+	}
+}
+
+// NewLimiter creates a limiter from the rate limit configuration
+// It fails on limits without a positive rate and a burst of at least one request
+func NewLimiter(cfg *config.RateLimitConfig, opts ...LimiterOption) (*Limiter, error) {
+	if err := validate("default limit", cfg.Default); err != nil {
+		return nil, err
+	}
+	for client, limit := range cfg.Clients {
+		if err := validate("limit for client "+client, limit); err != nil {
+			return nil, err
+		}
+	}
+	for route, limit := range cfg.Routes {
+		if err := validate("limit for route "+route, limit); err != nil {
+			return nil, err
+		}
+	}
+
+	l := &Limiter{
+		enabled: cfg.Enabled,
+		def:     cfg.Default,
+		clients: cfg.Clients,
+		routes:  cfg.Routes,
+		buckets: make(map[string]*bucket),
+		clock:   clock.New(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	l.lastSweep = l.clock.Now()
+	return l, nil
+}
+
+func validate(name string, limit config.RateLimit) error {
+	if limit.Rate <= 0 || limit.Burst < 1 {
+		return fmt.Errorf("%s must be rate:burst with a positive rate and burst, got %g:%d", name, limit.Rate, limit.Burst)
+	}
+	return nil
+}
+
+// Enabled reports whether requests are limited
+func (l *Limiter) Enabled() bool {
+	return l.enabled
+}
+
+// Allow checks a request to route and takes a token from each of its buckets if all have one
+// Callers are identified by their authenticated client name, or by ip when client is empty
+func (l *Limiter) Allow(client, ip, route string) Decision {
+	if !l.enabled {
+		return Decision{Allowed: true}
+	}
+
+	caller := "ip:" + ip
+	quota := l.def
+	if client != "" {
+		caller = "client:" + client
+		if limit, ok := l.clients[client]; ok {
+			quota = limit
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.sweep(now)
+
+	scopes := []string{ScopeClient}
+	buckets := []*bucket{l.bucket(ScopeClient+"|"+caller, quota, now)}
+	if limit, ok := l.routes[route]; ok {
+		scopes = append(scopes, ScopeRoute)
+		buckets = append(buckets, l.bucket(ScopeRoute+"|"+caller+"|"+route, limit, now))
+	}
+
+	// Reject with the bucket that takes longest to recover, so the retry is not rejected by another
+	decision := Decision{Allowed: true}
+	for i, b := range buckets {
+		if wait := b.wait(); wait > 0 && (decision.Allowed || wait > decision.RetryAfter) {
+			decision = Decision{Scope: scopes[i], Limit: b.limit, RetryAfter: wait}
+		}
+	}
+	if !decision.Allowed {
+		return decision
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return decision
+}
+
+// bucket returns the refilled bucket for key, creating a full one when missing
+// Configuration does not change at runtime, so an existing bucket keeps its limit
+func (l *Limiter) bucket(key string, limit config.RateLimit, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+		return b
+	}
+	b.refill(now)
+	return b
+}
+
+// sweep drops buckets that have refilled completely, as a new bucket would be identical
+// Callers seen once, such as scanners cycling through IP addresses, then do not accumulate
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Buckets returns the number of buckets currently tracked
+func (l *Limiter) Buckets() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
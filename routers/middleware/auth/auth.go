@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey carries the caller's API key
+const HeaderAPIKey = "X-API-Key"
+
+// HeaderTenant lets admins act for a tenant other than their own
+const HeaderTenant = "X-Tenant"
+
+// AuthMiddleware resolves the caller from the X-API-Key header or an "Authorization: Bearer" token
+// and stores the principal and the tenant it acts for in the request context. Invalid credentials are
+// rejected with 401; requests without credentials continue so public routes stay reachable, and Require
+// rejects them on protected routes. The tenant is the client's own unless an admin sends X-Tenant
+func AuthMiddleware(authenticator *auth.Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := authenticator.Authenticate(c.Request().Header.Get(HeaderAPIKey), bearerToken(c))
+			switch {
+			case errors.Is(err, auth.ErrMissingCredentials):
+				return next(c)
+			case err != nil:
+				return unauthorized(c, err)
+			}
+
+			requested := c.Request().Header.Get(HeaderTenant)
+			tenant, err := auth.ResolveTenant(principal, requested)
+			if err != nil {
+				apiErr := apierror.Tenant(principal.Client, requested, err)
+				return c.JSON(apiErr.Status, apiErr.Response())
+			}
+
+			// Picked up by the logging middleware
+			c.Set("client", principal.Client)
+			c.Set("tenant", tenant)
+			ctx := auth.WithTenant(auth.WithPrincipal(c.Request().Context(), principal), tenant)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// Require allows a route to principals with any of the roles; admins are always allowed
+func Require(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := auth.FromContext(c.Request().Context())
+			if principal == nil {
+				return unauthorized(c, auth.ErrMissingCredentials)
+			}
+
+			if !principal.HasRole(roles...) {
+				apiErr := apierror.Forbidden(principal.Client, roles)
+				return c.JSON(apiErr.Status, apiErr.Response())
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header, or an empty string
+func bearerToken(c echo.Context) string {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	if len(header) > 7 && strings.EqualFold(header[:7], "bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
+
+func unauthorized(c echo.Context, err error) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="volta-router"`)
+	apiErr := apierror.Unauthorized(err)
+	return c.JSON(apiErr.Status, apiErr.Response())
+}
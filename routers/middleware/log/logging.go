@@ -1,29 +1,76 @@
 package log
 
 import (
-	"log"
+	"log/slog"
+	"net/http"
 	"time"
+	"voltarides/smart-router/common/logger"
 
 	"github.com/labstack/echo/v4"
 )
 
-// LoggingMiddleware provides simple request logging
+// LoggingMiddleware writes one structured access log entry per request
+// A request-scoped logger carrying the request ID is placed in the request context
+// so service logs can be correlated with the access log
 func LoggingMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			start := time.Now()
 
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+			requestLogger := logger.FromContext(c.Request().Context()).With("request_id", requestID)
+			c.SetRequest(c.Request().WithContext(logger.WithContext(c.Request().Context(), requestLogger)))
+
 			// Process request
 			err := next(c)
 
-			// Log request details
-			log.Printf("[%s] %s %s - Status: %d - Duration: %v",
-				c.Request().Method,
-				c.Request().URL.Path,
-				c.Request().RemoteAddr,
-				c.Response().Status,
-				time.Since(start),
-			)
+			// Returned errors are written by Echo's error handler after this middleware runs
+			status := c.Response().Status
+			if err != nil {
+				status = http.StatusInternalServerError
+				if httpErr, ok := err.(*echo.HTTPError); ok {
+					status = httpErr.Code
+				}
+			}
+
+			attrs := []any{
+				"method", c.Request().Method,
+				"route", c.Path(),
+				"path", c.Request().URL.Path,
+				"status", status,
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000.0,
+				"remote_ip", c.RealIP(),
+			}
+
+			// Set by TraceIDMiddleware, AuthMiddleware and the routing controller when present
+			if traceID, ok := c.Get("trace_id").(string); ok {
+				attrs = append(attrs, "trace_id", traceID)
+			}
+			if client, ok := c.Get("client").(string); ok {
+				attrs = append(attrs, "client", client)
+			}
+			if tenant, ok := c.Get("tenant").(string); ok {
+				attrs = append(attrs, "tenant", tenant)
+			}
+			if processor, ok := c.Get("processor").(string); ok {
+				attrs = append(attrs, "processor", processor)
+			}
+			if riskLevel, ok := c.Get("risk_level").(string); ok {
+				attrs = append(attrs, "risk_level", riskLevel)
+			}
+			if err != nil {
+				attrs = append(attrs, "error", err.Error())
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= 500:
+				level = slog.LevelError
+			case status >= 400:
+				level = slog.LevelWarn
+			}
+
+			requestLogger.Log(c.Request().Context(), level, "http request", attrs...)
 
 			return err
 		}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+	"voltarides/smart-router/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MetricsMiddleware records route handler latency in the Prometheus registry
+func MetricsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			// Process request
+			err := next(c)
+
+			// Unmatched routes share a single label to keep cardinality bounded
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			// Returned errors are written by Echo's error handler after this middleware runs
+			status := c.Response().Status
+			if err != nil {
+				status = http.StatusInternalServerError
+				if httpErr, ok := err.(*echo.HTTPError); ok {
+					status = httpErr.Code
+				}
+			}
+
+			metrics.ObserveRequest(c.Request().Method, route, status, time.Since(start))
+
+			return err
+		}
+	}
+}
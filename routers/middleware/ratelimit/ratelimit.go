@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"strconv"
+	"strings"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/common/constants"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/ratelimit"
+
+	"github.com/labstack/echo/v4"
+)
+
+// v1 is the prefix removed from route paths, so limits are configured as e.g. /route
+var v1 = "/" + constants.MicroserviceName + constants.V1
+
+// RateLimitMiddleware rejects requests over the caller's quota or the route's limit with 429 and Retry-After
+// Authenticated callers are limited by client name and anonymous ones by IP address
+func RateLimitMiddleware(limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !limiter.Enabled() {
+				return next(c)
+			}
+
+			client := ""
+			if principal := auth.FromContext(c.Request().Context()); principal != nil && principal.Method != auth.MethodAnonymous {
+				client = principal.Client
+			}
+
+			route := strings.TrimPrefix(c.Path(), v1)
+			decision := limiter.Allow(client, c.RealIP(), route)
+			if decision.Allowed {
+				return next(c)
+			}
+
+			if client == "" {
+				client = auth.MethodAnonymous
+			}
+			metrics.RecordRateLimited(route, client, decision.Scope)
+
+			retryAfter := decision.RetryAfterSeconds()
+			c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			apiErr := apierror.RateLimited(decision.Scope, route, retryAfter)
+			return c.JSON(apiErr.Status, apiErr.Response())
+		}
+	}
+}
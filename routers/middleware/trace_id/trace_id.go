@@ -1,6 +1,9 @@
 package trace_id
 
 import (
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/telemetry"
+
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -9,19 +12,33 @@ import (
 func TraceIDMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			span := telemetry.SpanFromContext(c.Request().Context())
+
 			// Check if trace ID already exists in headers
 			traceID := c.Request().Header.Get("X-Trace-Id")
+			if traceID == "" {
+				// Reuse the tracing span's ID so logs and traces can be joined
+				traceID = span.TraceID()
+			}
 			if traceID == "" {
 				// Generate new trace ID
 				traceID = uuid.New().String()
 			}
 
+			// Link the trace ID to the request span
+			span.SetAttribute("trace_id", traceID)
+
 			// Set trace ID in response header
 			c.Response().Header().Set("X-Trace-Id", traceID)
 
 			// Store in context for potential use
 			c.Set("trace_id", traceID)
 
+			// Correlate service logs for this request with the trace
+			ctx := c.Request().Context()
+			ctx = logger.WithContext(ctx, logger.FromContext(ctx).With("trace_id", traceID))
+			c.SetRequest(c.Request().WithContext(ctx))
+
 			return next(c)
 		}
 	}
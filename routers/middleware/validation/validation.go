@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/openapi"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+)
+
+// route is an operation of the OpenAPI document matched to its Echo route
+type route struct {
+	route   *routers.Route
+	options *openapi3filter.Options
+}
+
+// ValidationMiddleware rejects requests whose parameters or body do not match the OpenAPI document
+// Routes missing from the document are passed through unchanged
+func ValidationMiddleware() echo.MiddlewareFunc {
+	doc := openapi.Document()
+
+	routes := make(map[string]route)
+	for _, op := range openapi.Operations() {
+		path := openapi.SpecPath(op.Path)
+		item := doc.Paths.Value(path)
+
+		// Credentials are checked by the auth middleware, not against the document's security schemes
+		options := &openapi3filter.Options{
+			ExcludeRequestBody: op.SkipBodyValidation,
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		}
+		options.WithCustomSchemaErrorFunc(schemaErrorMessage)
+
+		routes[op.Method+" "+op.Path] = route{
+			route: &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    op.Method,
+				Operation: item.GetOperation(op.Method),
+			},
+			options: options,
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			matched, ok := routes[c.Request().Method+" "+c.Path()]
+			if !ok {
+				return next(c)
+			}
+
+			pathParams := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				pathParams[name] = c.ParamValues()[i]
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    c.Request(),
+				PathParams: pathParams,
+				Route:      matched.route,
+				Options:    matched.options,
+			}
+			if err := openapi3filter.ValidateRequest(c.Request().Context(), input); err != nil {
+				apiErr := requestError(err)
+				return c.JSON(apiErr.Status, apiErr.Response())
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requestError maps a validation failure to the error codes the handlers use for the same problem
+func requestError(err error) *apierror.Error {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return apierror.Validation(err)
+	}
+
+	switch {
+	case reqErr.Parameter != nil:
+		return apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, reqErr.Error())
+	case reqErr.RequestBody != nil:
+		var parseErr *openapi3filter.ParseError
+		if errors.As(err, &parseErr) || reqErr.Err == nil {
+			return apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "Invalid request body: "+reqErr.Error())
+		}
+		return apierror.Validation(reqErr.Err)
+	default:
+		return apierror.Validation(reqErr)
+	}
+}
+
+// schemaErrorMessage names the offending field, e.g. "amount: number must be more than 0"
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	reason := err.Reason
+	if err.SchemaField == "format" {
+		// The default reason quotes the format's regular expression
+		reason = fmt.Sprintf("string doesn't match the format %q", err.Schema.Format)
+	}
+
+	field := strings.Join(err.JSONPointer(), ".")
+	if field == "" {
+		return reason
+	}
+	return field + ": " + reason
+}
@@ -1,51 +1,139 @@
 package routers
 
 import (
+	"voltarides/smart-router/auth"
 	"voltarides/smart-router/common/constants"
 	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/ratelimit"
+	middlewareAuth "voltarides/smart-router/routers/middleware/auth"
 	middlewareLog "voltarides/smart-router/routers/middleware/log"
+	middlewareMetrics "voltarides/smart-router/routers/middleware/metrics"
+	middlewareRateLimit "voltarides/smart-router/routers/middleware/ratelimit"
 	"voltarides/smart-router/routers/middleware/trace_id"
+	"voltarides/smart-router/routers/middleware/validation"
+	"voltarides/smart-router/telemetry"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	echoDatadog "gopkg.in/DataDog/dd-trace-go.v1/contrib/labstack/echo.v4"
 )
 
 // ConfigRouter configures all routes and middleware for the Echo server
 func ConfigRouter(
 	e *echo.Echo,
+	authenticator *auth.Authenticator,
+	limiter *ratelimit.Limiter,
+	corsOrigins []string,
 	routingController *controllers.RoutingController,
 	dataController *controllers.DataController,
+	circuitController *controllers.CircuitController,
+	alertController *controllers.AlertController,
+	webhookController *controllers.WebhookController,
+	streamController *controllers.StreamController,
 ) {
+	// Client IPs come from X-Forwarded-For entries added by trusted (private network) proxies only,
+	// so callers cannot pick the IP they are rate limited by
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
 	// Middleware stack (Yuno standard pattern)
-	// 1. DataDog APM (distributed tracing)
-	e.Use(echoDatadog.Middleware(echoDatadog.WithServiceName(constants.MicroserviceName)))
+	// 1. Distributed tracing (DataDog APM or OTLP, see telemetry.Init)
+	e.Use(telemetry.Middleware())
+
+	// 2. Request ID (X-Request-Id, generated when absent)
+	e.Use(middleware.RequestID())
 
-	// 2. Logging middleware
+	// 3. Structured logging middleware
 	e.Use(middlewareLog.LoggingMiddleware())
 
-	// 3. Trace ID propagation
+	// 4. Prometheus request latency
+	e.Use(middlewareMetrics.MetricsMiddleware())
+
+	// 5. Trace ID propagation
 	e.Use(trace_id.TraceIDMiddleware())
 
-	// 4. CORS middleware (for development)
-	e.Use(middleware.CORS())
+	// 6. CORS for the configured origins only (none by default outside development)
+	if len(corsOrigins) > 0 {
+		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins: corsOrigins,
+			AllowHeaders: []string{
+				echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept,
+				echo.HeaderAuthorization, middlewareAuth.HeaderAPIKey, middlewareAuth.HeaderTenant, echo.HeaderXRequestID,
+			},
+		}))
+	}
 
-	// 5. Recovery middleware (panic recovery)
+	// 7. Recovery middleware (panic recovery)
 	e.Use(middleware.Recover())
 
-	// Health check endpoint (root level)
-	e.GET(constants.HealthCheck, controllers.HealthCheck)
+	// 8. Authentication (API key or bearer token); roles are enforced per route below
+	e.Use(middlewareAuth.AuthMiddleware(authenticator))
+
+	// Per route: rate limits on versioned routes, role requirements (admins pass every check), then
+	// request validation against the OpenAPI document, so callers without access learn nothing from
+	// validation errors
+	limit := middlewareRateLimit.RateLimitMiddleware(limiter)
+	validate := validation.ValidationMiddleware()
+	protected := func(roles ...string) []echo.MiddlewareFunc {
+		return []echo.MiddlewareFunc{limit, middlewareAuth.Require(roles...), validate}
+	}
+	public := []echo.MiddlewareFunc{validate}
+	routing := protected(auth.RoleRouterClient)
+	reading := protected(auth.RoleRouterClient, auth.RoleOperator)
+	ingesting := protected(auth.RoleIngestWriter)
+	operating := protected(auth.RoleOperator)
+	administering := protected(auth.RoleAdmin)
+	// Metrics carry per-tenant approval rates, so scrapers need the operator role; they are not rate limited
+	scraping := []echo.MiddlewareFunc{middlewareAuth.Require(auth.RoleOperator), validate}
+
+	// Health check, metrics and API description endpoints (root level, not rate limited)
+	e.GET(constants.HealthCheck, controllers.HealthCheck, public...)
+	e.GET(constants.Metrics, echo.WrapHandler(metrics.Handler()), scraping...)
+	e.GET(constants.OpenAPI, controllers.GetOpenAPISpec, public...)
 
 	// API group with version
 	group := e.Group("/" + constants.MicroserviceName)
 	v1 := group.Group(constants.V1)
 
+	// Error code catalog (public)
+	v1.GET(constants.Errors, controllers.GetErrorCatalog, limit, validate)
+
 	// Routing endpoints
-	v1.POST(constants.Route, routingController.RouteTransaction)
-	v1.GET(constants.Processors, routingController.GetProcessorHealth)
-	v1.GET(constants.ProcessorByName, routingController.GetProcessorByName)
-	v1.GET(constants.RoutingStats, routingController.GetRoutingStats)
+	v1.POST(constants.Route, routingController.RouteTransaction, routing...)
+	v1.POST(constants.RouteWhatIf, routingController.WhatIfRouting, reading...)
+	v1.POST(constants.RouteBatch, routingController.RouteBatch, routing...)
+	v1.GET(constants.Processors, routingController.GetProcessorHealth, reading...)
+	v1.GET(constants.ProcessorByName, routingController.GetProcessorByName, reading...)
+	v1.GET(constants.ProcessorStream, streamController.StreamProcessorHealth, reading...)
+	v1.GET(constants.ProcessorTimeSeries, routingController.GetProcessorTimeSeries, reading...)
+	v1.GET(constants.RoutingStats, routingController.GetRoutingStats, reading...)
+	v1.GET(constants.RoutingDecisions, routingController.GetRoutingDecisions, reading...)
+	v1.GET(constants.RoutingDecision, routingController.GetRoutingDecisionByID, reading...)
+	v1.GET(constants.RoutingEffectiveness, routingController.GetRoutingEffectiveness, reading...)
+
+	// Circuit breaker control endpoints
+	v1.POST(constants.CircuitOpen, circuitController.OpenCircuit, operating...)
+	v1.POST(constants.CircuitClose, circuitController.CloseCircuit, operating...)
+	v1.POST(constants.CircuitPin, circuitController.PinCircuit, operating...)
+	v1.DELETE(constants.CircuitOverride, circuitController.ReleaseCircuit, operating...)
+	v1.GET(constants.CircuitHistory, circuitController.GetCircuitHistory, reading...)
+
+	// Alerting endpoints
+	v1.GET(constants.Alerts, alertController.GetAlerts, reading...)
+
+	// Webhook endpoints
+	v1.POST(constants.Webhooks, webhookController.CreateSubscription, operating...)
+	v1.GET(constants.Webhooks, webhookController.GetSubscriptions, operating...)
+	v1.DELETE(constants.Webhook, webhookController.DeleteSubscription, operating...)
+	v1.GET(constants.WebhookDeliveries, webhookController.GetDeliveries, operating...)
+	v1.GET(constants.WebhookDeadLetters, webhookController.GetDeadLetters, operating...)
+	v1.POST(constants.WebhookDeadLetter, webhookController.RetryDeadLetter, operating...)
 
 	// Data management endpoints
-	v1.POST(constants.TransactionsLoad, dataController.LoadTestData)
+	v1.POST(constants.Transactions, routingController.RecordTransactionOutcome, ingesting...)
+	v1.POST(constants.TransactionsLoad, dataController.LoadTestData, administering...)
+
+	// Admin store endpoints
+	v1.POST(constants.AdminSnapshots, dataController.CreateSnapshot, administering...)
+	v1.POST(constants.AdminSnapshotRestore, dataController.RestoreSnapshot, administering...)
+	v1.POST(constants.AdminStoreClear, dataController.ClearStore, administering...)
 }
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/telemetry"
+)
+
+// rateCache memoises approval rates for the duration of a batch, so each processor's window is scanned once
+type rateCache struct {
+	rates map[string]float64 // key: "processor:country"
+}
+
+func newRateCache() *rateCache {
+	return &rateCache{rates: make(map[string]float64)}
+}
+
+// cachedApprovalRate returns the approval rate from cache, calculating and storing it on a miss
+// A nil cache always calculates
+func (s *RoutingService) cachedApprovalRate(ctx context.Context, cache *rateCache, processor, country string) float64 {
+	if cache == nil {
+		return s.approvalRate(ctx, processor, country)
+	}
+
+	key := processor + ":" + country
+	if rate, exists := cache.rates[key]; exists {
+		return rate
+	}
+
+	rate := s.approvalRate(ctx, processor, country)
+	cache.rates[key] = rate
+	return rate
+}
+
+// SelectBestProcessorBatch routes requests in order and returns a response or an error for each, by index
+//
+// Approval rates are calculated once per processor for the whole batch. Requests share one circuit
+// view, so a circuit opened while routing one request is already open for the next; in simulation
+// mode that view is simulated and each response lists the transitions its request would have applied
+func (s *RoutingService) SelectBestProcessorBatch(ctx context.Context, reqs []models.RoutingRequest, simulate bool, includeFailover bool) ([]*models.RoutingResponse, []error) {
+	ctx, span := telemetry.StartSpan(ctx, "routing.select_processor_batch")
+	defer span.End()
+	span.SetAttribute("requests", len(reqs))
+	span.SetAttribute("simulate", simulate)
+
+	var circuits circuitView = &liveCircuits{s: s}
+	var simulated *simulatedCircuits
+	if simulate {
+		simulated = newSimulatedCircuits(s)
+		circuits = simulated
+	}
+
+	cache := newRateCache()
+	responses := make([]*models.RoutingResponse, len(reqs))
+	errs := make([]error, len(reqs))
+	failed := 0
+
+	for i, req := range reqs {
+		transitionsBefore := 0
+		if simulated != nil {
+			transitionsBefore = len(simulated.transitions)
+		}
+
+		response, _, err := s.route(ctx, req, circuits, cache, !simulate, includeFailover)
+		if err != nil {
+			errs[i] = err
+			failed++
+			continue
+		}
+
+		if simulated != nil && len(simulated.transitions) > transitionsBefore {
+			response.SimulatedTransitions = simulated.transitions[transitionsBefore:]
+		}
+		responses[i] = response
+	}
+
+	span.SetAttribute("failed", failed)
+	return responses, errs
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/models"
+)
+
+// circuitView is the circuit breaker state selectProcessor reads and transitions
+type circuitView interface {
+	state(ctx context.Context, processor, country string) models.CircuitState
+	pinned(ctx context.Context, processor, country string) bool
+	open(ctx context.Context, processor, country string, from models.CircuitState, rate float64)
+	close(ctx context.Context, processor, country string, from models.CircuitState, rate float64)
+}
+
+// liveCircuits reads breaker state from the store and applies transitions to it
+type liveCircuits struct {
+	s *RoutingService
+}
+
+func (v *liveCircuits) state(ctx context.Context, processor, country string) models.CircuitState {
+	v.s.syncCircuit(processor, country)
+	return v.s.circuitState(ctx, processor, country)
+}
+
+func (v *liveCircuits) pinned(ctx context.Context, processor, country string) bool {
+	return v.s.circuitPinned(ctx, processor, country)
+}
+
+func (v *liveCircuits) open(ctx context.Context, processor, country string, from models.CircuitState, rate float64) {
+	span := startStoreSpan(ctx, "OpenCircuit", processor, country)
+	defer span.End()
+
+	v.s.store.OpenCircuit(v.s.tenant, processor, country)
+	v.s.recordCircuitEvent(v.s.newCircuitEvent(processor, country, from, models.CircuitOpen, rate, models.CircuitTriggerLowApprovalRate))
+
+	logger.FromContext(ctx).Warn("circuit opened",
+		"event", "circuit_transition",
+		"tenant", v.s.tenant,
+		"processor", processor,
+		"country", country,
+		"from_state", from,
+		"to_state", models.CircuitOpen,
+		"approval_rate", rate,
+	)
+}
+
+func (v *liveCircuits) close(ctx context.Context, processor, country string, from models.CircuitState, rate float64) {
+	span := startStoreSpan(ctx, "CloseCircuit", processor, country)
+	defer span.End()
+
+	v.s.store.CloseCircuit(v.s.tenant, processor, country)
+	v.s.recordCircuitEvent(v.s.newCircuitEvent(processor, country, from, models.CircuitClosed, rate, models.CircuitTriggerRecovered))
+
+	logger.FromContext(ctx).Info("circuit closed",
+		"event", "circuit_transition",
+		"tenant", v.s.tenant,
+		"processor", processor,
+		"country", country,
+		"from_state", from,
+		"to_state", models.CircuitClosed,
+		"approval_rate", rate,
+	)
+}
+
+// simulatedCircuits reads breaker state from the store but only collects transitions,
+// so a dry-run can never change production routing
+type simulatedCircuits struct {
+	s           *RoutingService
+	overlay     map[string]models.CircuitState // key: "processor:country"; the view covers one tenant
+	transitions []models.CircuitEvent
+}
+
+func newSimulatedCircuits(s *RoutingService) *simulatedCircuits {
+	return &simulatedCircuits{
+		s:       s,
+		overlay: make(map[string]models.CircuitState),
+	}
+}
+
+func (v *simulatedCircuits) state(ctx context.Context, processor, country string) models.CircuitState {
+	if state, exists := v.overlay[processor+":"+country]; exists {
+		return state
+	}
+	return v.s.circuitState(ctx, processor, country)
+}
+
+func (v *simulatedCircuits) pinned(ctx context.Context, processor, country string) bool {
+	return v.s.circuitPinned(ctx, processor, country)
+}
+
+func (v *simulatedCircuits) open(ctx context.Context, processor, country string, from models.CircuitState, rate float64) {
+	v.overlay[processor+":"+country] = models.CircuitOpen
+	v.transitions = append(v.transitions, v.s.newCircuitEvent(processor, country, from, models.CircuitOpen, rate, models.CircuitTriggerLowApprovalRate))
+}
+
+func (v *simulatedCircuits) close(ctx context.Context, processor, country string, from models.CircuitState, rate float64) {
+	v.overlay[processor+":"+country] = models.CircuitClosed
+	v.transitions = append(v.transitions, v.s.newCircuitEvent(processor, country, from, models.CircuitClosed, rate, models.CircuitTriggerRecovered))
+}
+
+// newCircuitEvent builds an automatic circuit transition event
+func (s *RoutingService) newCircuitEvent(processor, country string, from, to models.CircuitState, rate float64, trigger string) models.CircuitEvent {
+	return models.CircuitEvent{
+		Tenant:       s.tenant,
+		Processor:    processor,
+		Country:      country,
+		FromState:    from,
+		ToState:      to,
+		ApprovalRate: rate,
+		Trigger:      trigger,
+		Timestamp:    s.clock.Now(),
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+
+	"github.com/google/uuid"
+)
+
+// RecordOutcome stores the outcome of a payment, linking it to its routing decision when a decision ID is given
+func (s *RoutingService) RecordOutcome(req models.TransactionOutcomeRequest) (*models.Transaction, error) {
+	tx := models.Transaction{
+		ID:         req.ID,
+		Tenant:     s.tenant,
+		Processor:  req.Processor,
+		Country:    req.Country,
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		Status:     req.Status,
+		Timestamp:  req.Timestamp,
+		DecisionID: req.DecisionID,
+	}
+
+	if tx.DecisionID != "" {
+		decision := s.store.GetRoutingDecision(tx.DecisionID)
+		if decision == nil || decision.Tenant != s.tenant {
+			return nil, fmt.Errorf("routing decision %s not found", tx.DecisionID)
+		}
+		if decision.Country != tx.Country {
+			return nil, fmt.Errorf("routing decision %s was made for country %s, not %s", tx.DecisionID, decision.Country, tx.Country)
+		}
+		if tx.Processor == "" {
+			tx.Processor = decision.Processor
+		}
+	}
+
+	if !s.HasProcessor(tx.Processor, tx.Country) {
+		return nil, fmt.Errorf("processor %s not found for country %s", tx.Processor, tx.Country)
+	}
+
+	if tx.ID == "" {
+		tx.ID = uuid.New().String()
+	}
+	if tx.Timestamp.IsZero() {
+		tx.Timestamp = s.clock.Now()
+	}
+
+	s.store.AddTransaction(tx)
+	if s.recordMetrics {
+		metrics.RecordTransactionsIngested([]models.Transaction{tx})
+	}
+	return &tx, nil
+}
+
+// GetRoutingEffectiveness compares the realised approval rate of routed traffic with the router's
+// predictions and with the best alternative it passed over, overall and per routed processor
+//
+// Only outcomes on the routed processor count towards its approval rate; an outcome on another
+// processor means the caller fell back. Regret is the mean rate of the runner-up candidate minus
+// the realised approval rate of decisions that had one, in percentage points
+func (s *RoutingService) GetRoutingEffectiveness(country string, from, to time.Time) (*models.RoutingEffectivenessResponse, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	overall := &effectivenessAccumulator{}
+	byProcessor := make(map[string]*effectivenessAccumulator)
+	order := make([]string, 0)
+
+	for _, entry := range s.store.GetDecisionOutcomes(s.tenant, country, from, to) {
+		key := entry.Decision.Processor + ":" + entry.Decision.Country
+		accumulator, exists := byProcessor[key]
+		if !exists {
+			accumulator = &effectivenessAccumulator{processor: entry.Decision.Processor, country: entry.Decision.Country}
+			byProcessor[key] = accumulator
+			order = append(order, key)
+		}
+
+		overall.add(entry)
+		accumulator.add(entry)
+	}
+
+	response := &models.RoutingEffectivenessResponse{
+		Overall:    overall.result(),
+		Processors: make([]models.ProcessorEffectiveness, 0, len(order)),
+	}
+	for _, key := range order {
+		accumulator := byProcessor[key]
+		response.Processors = append(response.Processors, models.ProcessorEffectiveness{
+			Processor:            accumulator.processor,
+			Country:              accumulator.country,
+			RoutingEffectiveness: accumulator.result(),
+		})
+	}
+
+	return response, nil
+}
+
+// effectivenessAccumulator sums decision outcomes for one group of decisions
+type effectivenessAccumulator struct {
+	processor string
+	country   string
+
+	decisions      int
+	outcomes       int
+	approved       int
+	fallbackUsed   int
+	predictedSum   float64
+	regretSamples  int
+	alternativeSum float64
+	regretApproved int
+}
+
+// add accounts for one decision and its linked outcomes
+func (a *effectivenessAccumulator) add(entry storage.DecisionOutcome) {
+	a.decisions++
+
+	routed := false
+	approved := false
+	fellBack := false
+	for _, outcome := range entry.Outcomes {
+		if outcome.Processor != entry.Decision.Processor {
+			fellBack = true
+			continue
+		}
+		routed = true
+		if outcome.IsApproved() {
+			approved = true
+		}
+	}
+
+	if fellBack {
+		a.fallbackUsed++
+	}
+	if !routed {
+		return
+	}
+
+	a.outcomes++
+	a.predictedSum += entry.Decision.ApprovalRate
+	if approved {
+		a.approved++
+	}
+
+	// The runner-up is the second ranked candidate recorded with the decision
+	if len(entry.Decision.Candidates) > 1 {
+		a.regretSamples++
+		a.alternativeSum += entry.Decision.Candidates[1].ApprovalRate
+		if approved {
+			a.regretApproved++
+		}
+	}
+}
+
+// result converts the sums into rates
+func (a *effectivenessAccumulator) result() models.RoutingEffectiveness {
+	result := models.RoutingEffectiveness{
+		Decisions:    a.decisions,
+		Outcomes:     a.outcomes,
+		Approved:     a.approved,
+		FallbackUsed: a.fallbackUsed,
+	}
+
+	if a.outcomes > 0 {
+		result.ApprovalRate = (float64(a.approved) / float64(a.outcomes)) * 100.0
+		result.PredictedApprovalRate = a.predictedSum / float64(a.outcomes)
+	}
+
+	if a.regretSamples > 0 {
+		result.BestAlternativeRate = a.alternativeSum / float64(a.regretSamples)
+		result.Regret = result.BestAlternativeRate - (float64(a.regretApproved)/float64(a.regretSamples))*100.0
+	}
+
+	return result
+}
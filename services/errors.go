@@ -0,0 +1,34 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by routing; match them with errors.Is
+// The returned errors carry a message naming the country (and currency) they apply to
+var (
+	ErrUnsupportedCountry = errors.New("country not supported")
+	ErrInvalidCurrency    = errors.New("currency not accepted for country")
+	ErrNoData             = errors.New("no processor data available")
+	ErrAllCircuitsOpen    = errors.New("all processor circuits are open")
+)
+
+// routingError is an error with a specific message that matches one of the sentinel errors
+type routingError struct {
+	kind    error
+	message string
+}
+
+func (e *routingError) Error() string {
+	return e.message
+}
+
+func (e *routingError) Unwrap() error {
+	return e.kind
+}
+
+// newRoutingError returns an error with a formatted message that matches kind
+func newRoutingError(kind error, format string, args ...interface{}) error {
+	return &routingError{kind: kind, message: fmt.Sprintf(format, args...)}
+}
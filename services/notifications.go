@@ -0,0 +1,74 @@
+package services
+
+import (
+	"time"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/models"
+)
+
+// recordCircuitEvent stores a circuit transition and publishes it when the circuit changed state
+func (s *RoutingService) recordCircuitEvent(event models.CircuitEvent) {
+	s.store.RecordCircuitEvent(event)
+
+	if event.FromState == event.ToState {
+		return
+	}
+
+	switch event.ToState {
+	case models.CircuitOpen:
+		s.publish(events.CircuitOpened, event.Timestamp, event)
+	case models.CircuitClosed:
+		s.publish(events.CircuitClosed, event.Timestamp, event)
+	case models.CircuitHalfOpen:
+		s.publish(events.CircuitHalfOpened, event.Timestamp, event)
+	}
+}
+
+// publish publishes an event of the service's tenant
+func (s *RoutingService) publish(eventType string, timestamp time.Time, data interface{}) {
+	event := events.New(eventType, timestamp, data)
+	event.Tenant = s.tenant
+	s.events.Publish(event)
+}
+
+// syncCircuit records the transitions of a processor's breaker that happen with time, such as the timeout
+// turning an open breaker half-open or a manual override expiring. They are recorded when the breaker is
+// first read live after they took effect; simulations read the breaker without syncing it
+func (s *RoutingService) syncCircuit(processor, country string) {
+	for _, event := range s.store.SyncCircuit(s.tenant, processor, country, s.config.CircuitBreakerTimeout) {
+		event.ApprovalRate = s.CalculateApprovalRate(processor, country)
+		s.recordCircuitEvent(event)
+	}
+}
+
+// updateCountryStatus tracks the routing status of a tenant's country and publishes an event when it changes
+// Only live routing updates the status; the first observation of a healthy country is not an event
+func (s *RoutingService) updateCountryStatus(country, status, processor string, rate float64, reason string) {
+	key := s.tenant + ":" + country
+	s.countryStatus.mu.Lock()
+	previous := s.countryStatus.status[key]
+	s.countryStatus.status[key] = status
+	s.countryStatus.mu.Unlock()
+
+	if previous == status || (previous == "" && status == models.CountryStatusAvailable) {
+		return
+	}
+
+	eventType := events.CountryRecovered
+	switch status {
+	case models.CountryStatusHighRisk:
+		eventType = events.CountryHighRisk
+	case models.CountryStatusUnavailable:
+		eventType = events.CountryUnavailable
+	}
+
+	s.publish(eventType, s.clock.Now(), models.CountryStatusChange{
+		Tenant:       s.tenant,
+		Country:      country,
+		FromStatus:   previous,
+		ToStatus:     status,
+		Processor:    processor,
+		ApprovalRate: rate,
+		Reason:       reason,
+	})
+}
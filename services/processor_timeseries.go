@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"voltarides/smart-router/models"
+)
+
+// defaultTimeSeriesRange is the span covered when no from is given
+const defaultTimeSeriesRange = time.Hour
+
+// GetProcessorTimeSeries returns a processor's approved and declined counts per bucket in [from, to],
+// with the circuit breaker states it went through overlaid
+//
+// A zero to means now and a zero from means one hour before to. Circuit states are rebuilt from the
+// recorded transitions: an automatically opened circuit is shown half-open once the breaker timeout has
+// passed, while manually pinned states last until the next recorded transition
+func (s *RoutingService) GetProcessorTimeSeries(name string, from, to time.Time, bucket time.Duration) (*models.ProcessorTimeSeriesResponse, error) {
+	country, exists := s.ProcessorCountry(name)
+	if !exists {
+		return nil, fmt.Errorf("processor %s not found", name)
+	}
+
+	if to.IsZero() {
+		to = s.clock.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultTimeSeriesRange)
+	}
+	if to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if bucket == 0 {
+		bucket = time.Minute
+	}
+	if bucket < time.Second {
+		return nil, errors.New("bucket must be at least 1s")
+	}
+
+	start := from.Truncate(bucket)
+	count := int(to.Sub(start)/bucket) + 1
+	if count > maxStatsBuckets {
+		return nil, fmt.Errorf("bucket %s yields %d buckets, at most %d are allowed", bucket, count, maxStatsBuckets)
+	}
+
+	intervals := s.circuitIntervals(name, country, from, to)
+
+	buckets := make([]models.ProcessorTimeSeriesBucket, count)
+	for i := range buckets {
+		bucketStart := start.Add(time.Duration(i) * bucket)
+		bucketEnd := bucketStart.Add(bucket)
+		if bucketEnd.After(to) {
+			bucketEnd = to
+		}
+		buckets[i] = models.ProcessorTimeSeriesBucket{
+			Start:        bucketStart,
+			CircuitState: circuitStateAt(intervals, bucketEnd),
+		}
+	}
+
+	for _, tx := range s.store.GetTransactionsByRange(s.tenant, name, country, from, to) {
+		index := int(tx.Timestamp.Sub(start) / bucket)
+		if index < 0 || index >= count {
+			continue
+		}
+		buckets[index].Total++
+		if tx.IsApproved() {
+			buckets[index].Approved++
+		} else {
+			buckets[index].Declined++
+		}
+	}
+
+	for i := range buckets {
+		if buckets[i].Total > 0 {
+			rate := (float64(buckets[i].Approved) / float64(buckets[i].Total)) * 100.0
+			buckets[i].ApprovalRate = &rate
+		}
+	}
+
+	return &models.ProcessorTimeSeriesResponse{
+		Processor:     name,
+		Country:       country,
+		From:          from,
+		To:            to,
+		Bucket:        bucket.String(),
+		Buckets:       buckets,
+		CircuitStates: intervals,
+	}, nil
+}
+
+// ProcessorCountry returns the country a processor of the tenant is configured for
+func (s *RoutingService) ProcessorCountry(name string) (string, bool) {
+	for country := range s.processors() {
+		if s.HasProcessor(name, country) {
+			return country, true
+		}
+	}
+	return "", false
+}
+
+// circuitIntervals replays the recorded circuit transitions of a processor into state intervals covering [from, to]
+func (s *RoutingService) circuitIntervals(processor, country string, from, to time.Time) []models.CircuitStateInterval {
+	intervals := make([]models.CircuitStateInterval, 0)
+
+	state := models.CircuitClosed
+	trigger := ""
+	changedAt := time.Time{}
+	since := from
+
+	// emit closes the current state at until, splitting automatic opens at the breaker timeout
+	emit := func(until time.Time) {
+		if !until.After(since) {
+			return
+		}
+
+		if state == models.CircuitOpen && trigger != models.CircuitTriggerManualOverride {
+			halfOpenAt := changedAt.Add(s.config.CircuitBreakerTimeout)
+			if halfOpenAt.Before(until) {
+				if halfOpenAt.After(since) {
+					intervals = append(intervals, models.CircuitStateInterval{State: state, From: since, To: halfOpenAt, Trigger: intervalTrigger(trigger, changedAt, since)})
+					since = halfOpenAt
+				}
+				intervals = append(intervals, models.CircuitStateInterval{State: models.CircuitHalfOpen, From: since, To: until})
+				since = until
+				return
+			}
+		}
+
+		intervals = append(intervals, models.CircuitStateInterval{State: state, From: since, To: until, Trigger: intervalTrigger(trigger, changedAt, since)})
+		since = until
+	}
+
+	for _, event := range s.store.GetCircuitEvents(s.tenant, processor, country, time.Time{}, to) {
+		if event.Timestamp.After(from) {
+			emit(event.Timestamp)
+		}
+		state = event.ToState
+		trigger = event.Trigger
+		changedAt = event.Timestamp
+	}
+	emit(to)
+
+	return intervals
+}
+
+// intervalTrigger returns the trigger of the transition that starts an interval at since, if any
+func intervalTrigger(trigger string, changedAt, since time.Time) string {
+	if changedAt.Equal(since) {
+		return trigger
+	}
+	return ""
+}
+
+// circuitStateAt returns the state of the interval containing t; on a boundary the later interval wins
+func circuitStateAt(intervals []models.CircuitStateInterval, t time.Time) models.CircuitState {
+	for i := len(intervals) - 1; i >= 0; i-- {
+		if !t.Before(intervals[i].From) && !t.After(intervals[i].To) {
+			return intervals[i].State
+		}
+	}
+	return models.CircuitClosed
+}
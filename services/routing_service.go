@@ -1,33 +1,157 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/common/logger"
 	"voltarides/smart-router/config"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/metrics"
 	"voltarides/smart-router/models"
 	"voltarides/smart-router/storage"
+	"voltarides/smart-router/telemetry"
+
+	"github.com/google/uuid"
 )
 
 // RoutingService handles routing logic and approval rate calculations
+// A service routes for one tenant; ForTenant returns the service of another tenant sharing the same store
 type RoutingService struct {
 	store  *storage.InMemoryStore
-	config *config.RoutingConfig
+	config *config.RoutingConfig // Configuration of the service's tenant
+	clock  clock.Clock
+	events *events.Bus
+	tenant string
+
+	recordMetrics bool // Count routing decisions in the process-wide Prometheus registry
+
+	defaultConfig *config.RoutingConfig            // Configuration of tenants without their own
+	tenantConfigs map[string]*config.RoutingConfig // key: tenant
+
+	countryStatus *countryStatus // Shared by the services of every tenant
+}
+
+// countryStatus tracks the last routing status per tenant and country, for change notifications
+type countryStatus struct {
+	status map[string]string // key: "tenant:country"
+	mu     sync.Mutex
+}
+
+// RoutingServiceOption configures a RoutingService
+type RoutingServiceOption func(*RoutingService)
+
+// WithClock sets the clock used for decision timestamps and circuit overrides
+// By default the service shares the store's clock
+func WithClock(clk clock.Clock) RoutingServiceOption {
+	return func(s *RoutingService) {
+		s.clock = clk
+	}
+}
+
+// WithEventBus publishes circuit transitions and country status changes to bus
+func WithEventBus(bus *events.Bus) RoutingServiceOption {
+	return func(s *RoutingService) {
+		s.events = bus
+	}
+}
+
+// WithTenantConfigs routes the listed tenants with their own configuration instead of the service's
+func WithTenantConfigs(configs map[string]*config.RoutingConfig) RoutingServiceOption {
+	return func(s *RoutingService) {
+		s.tenantConfigs = configs
+	}
+}
+
+// WithoutMetrics keeps the service's routing decisions out of the process-wide Prometheus registry,
+// for offline replays such as backtests
+func WithoutMetrics() RoutingServiceOption {
+	return func(s *RoutingService) {
+		s.recordMetrics = false
+	}
 }
 
 // NewRoutingService creates a new routing service
-func NewRoutingService(store *storage.InMemoryStore, cfg *config.RoutingConfig) *RoutingService {
-	return &RoutingService{
-		store:  store,
-		config: cfg,
+func NewRoutingService(store *storage.InMemoryStore, cfg *config.RoutingConfig, opts ...RoutingServiceOption) *RoutingService {
+	service := &RoutingService{
+		store:         store,
+		config:        cfg,
+		clock:         store.Clock(),
+		tenant:        config.DefaultTenant,
+		recordMetrics: true,
+		defaultConfig: cfg,
+		countryStatus: &countryStatus{status: make(map[string]string)},
+	}
+
+	for _, opt := range opts {
+		opt(service)
 	}
+	service.config = service.configFor(service.tenant)
+
+	return service
+}
+
+// ForTenant returns a service routing with the processors, transactions, circuits and configuration of tenant
+// It shares the store and event bus of s
+func (s *RoutingService) ForTenant(tenant string) *RoutingService {
+	service := *s
+	service.tenant = config.TenantOrDefault(tenant)
+	service.config = s.configFor(service.tenant)
+	return &service
+}
+
+// configFor returns the routing configuration of a tenant
+func (s *RoutingService) configFor(tenant string) *config.RoutingConfig {
+	if cfg, exists := s.tenantConfigs[tenant]; exists {
+		return cfg
+	}
+	return s.defaultConfig
+}
+
+// Tenant returns the tenant the service routes for
+func (s *RoutingService) Tenant() string {
+	return s.tenant
+}
+
+// processors returns the tenant's processors by country
+func (s *RoutingService) processors() map[string][]string {
+	return config.ProcessorsByTenant[s.tenant]
+}
+
+// Countries returns the countries the tenant routes payments in, in a stable order
+func (s *RoutingService) Countries() []string {
+	countries := make([]string, 0, len(s.processors()))
+	for country := range s.processors() {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
 }
 
 // CalculateApprovalRate calculates the approval rate for a processor in a specific country
 func (s *RoutingService) CalculateApprovalRate(processor, country string) float64 {
-	transactions := s.store.GetTransactionsByWindow(processor, country, s.config.TimeWindow)
+	return s.approvalRate(context.Background(), processor, country)
+}
+
+// approvalRate calculates the approval rate inside a span linked to the caller's trace
+func (s *RoutingService) approvalRate(ctx context.Context, processor, country string) float64 {
+	ctx, span := telemetry.StartSpan(ctx, "routing.approval_rate")
+	defer span.End()
+	span.SetAttribute("tenant", s.tenant)
+	span.SetAttribute("processor", processor)
+	span.SetAttribute("country", country)
+
+	storeSpan := startStoreSpan(ctx, "GetTransactionsByWindow", processor, country)
+	transactions := s.store.GetTransactionsByWindow(s.tenant, processor, country, s.config.TimeWindow)
+	storeSpan.SetAttribute("transactions", len(transactions))
+	storeSpan.End()
 
 	if len(transactions) == 0 {
+		span.SetAttribute("approval_rate", 0.0)
 		return 0.0
 	}
 
@@ -38,61 +162,127 @@ func (s *RoutingService) CalculateApprovalRate(processor, country string) float6
 		}
 	}
 
-	return (float64(approved) / float64(len(transactions))) * 100.0
+	rate := (float64(approved) / float64(len(transactions))) * 100.0
+	span.SetAttribute("approval_rate", rate)
+	return rate
 }
 
 // SelectBestProcessor selects the best processor for a routing request
-func (s *RoutingService) SelectBestProcessor(req models.RoutingRequest, simulate bool) (*models.RoutingResponse, error) {
-	return s.selectProcessor(req, simulate, false)
+func (s *RoutingService) SelectBestProcessor(ctx context.Context, req models.RoutingRequest, simulate bool) (*models.RoutingResponse, error) {
+	return s.selectProcessor(ctx, req, simulate, false)
 }
 
 // SelectBestProcessorWithFailover selects the best processor and provides failover options
-func (s *RoutingService) SelectBestProcessorWithFailover(req models.RoutingRequest, simulate bool) (*models.RoutingResponse, error) {
-	return s.selectProcessor(req, simulate, true)
+func (s *RoutingService) SelectBestProcessorWithFailover(ctx context.Context, req models.RoutingRequest, simulate bool) (*models.RoutingResponse, error) {
+	return s.selectProcessor(ctx, req, simulate, true)
+}
+
+// processorRate is a candidate processor with its approval rate in the current window
+type processorRate struct {
+	name  string
+	rate  float64
+	state models.CircuitState // Breaker state after any transition applied by this request
 }
 
 // selectProcessor is the internal implementation for processor selection
-func (s *RoutingService) selectProcessor(req models.RoutingRequest, simulate bool, includeFailover bool) (*models.RoutingResponse, error) {
+func (s *RoutingService) selectProcessor(ctx context.Context, req models.RoutingRequest, simulate bool, includeFailover bool) (*models.RoutingResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "routing.select_processor")
+	defer span.End()
+	span.SetAttribute("tenant", s.tenant)
+	span.SetAttribute("country", req.Country)
+	span.SetAttribute("simulate", simulate)
+
+	// Simulations read breaker state but never write it back to the store
+	var circuits circuitView = &liveCircuits{s: s}
+	var simulated *simulatedCircuits
+	if simulate {
+		simulated = newSimulatedCircuits(s)
+		circuits = simulated
+	}
+
+	response, _, err := s.route(ctx, req, circuits, nil, !simulate, includeFailover)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttribute("processor", response.Processor)
+	span.SetAttribute("risk_level", response.RiskLevel)
+
+	// Report the transitions a live request would have applied
+	if simulated != nil {
+		response.SimulatedTransitions = simulated.transitions
+	}
+
+	return response, nil
+}
+
+// route ranks the processors of a country against the given circuit view and builds the response
+// The ranked candidates are returned alongside the response for callers that need the full ranking.
+// Approval rates are memoised in cache when one is given
+func (s *RoutingService) route(ctx context.Context, req models.RoutingRequest, circuits circuitView, cache *rateCache, record bool, includeFailover bool) (*models.RoutingResponse, []processorRate, error) {
 	// Validate country
-	processors, exists := config.ProcessorsByCountry[req.Country]
+	processors, exists := s.processors()[req.Country]
 	if !exists {
-		return nil, fmt.Errorf("country %s not supported", req.Country)
+		return nil, nil, newRoutingError(ErrUnsupportedCountry, "country %s not supported", req.Country)
 	}
 
-	if len(processors) == 0 {
-		return nil, errors.New("no processors available for country " + req.Country)
+	if currency, exists := config.CurrencyByCountry[req.Country]; exists && req.Currency != currency {
+		return nil, nil, newRoutingError(ErrInvalidCurrency, "currency %s not accepted for country %s (expected %s)", req.Currency, req.Country, currency)
 	}
 
-	// Calculate approval rates for all processors in this country
-	type processorRate struct {
-		name string
-		rate float64
+	if len(processors) == 0 {
+		return nil, nil, newRoutingError(ErrNoData, "no processors available for country %s", req.Country)
 	}
 
+	// Calculate approval rates for all processors in this country
 	rates := make([]processorRate, 0, len(processors))
+	excluded := make([]models.ExcludedProcessor, 0)
 	for _, processor := range processors {
 		// Check circuit breaker state
-		circuitState := s.store.GetCircuitState(processor, req.Country, s.config.CircuitBreakerTimeout)
+		circuitState := circuits.state(ctx, processor, req.Country)
 
 		// Skip processors with open circuit breaker
 		if circuitState == models.CircuitOpen {
+			exclusion := models.ExcludedProcessor{
+				Processor:    processor,
+				Reason:       models.ExclusionCircuitOpen,
+				Detail:       "circuit breaker is open",
+				CircuitState: circuitState,
+			}
+			if circuits.pinned(ctx, processor, req.Country) {
+				exclusion.Reason = models.ExclusionCircuitForcedOpen
+				exclusion.Detail = "circuit breaker is pinned open by a manual override"
+			}
+			excluded = append(excluded, exclusion)
 			continue
 		}
 
-		rate := s.CalculateApprovalRate(processor, req.Country)
-
-		// Check if circuit should be opened
-		if rate > 0 && rate < s.config.CircuitBreakerThreshold {
-			s.store.OpenCircuit(processor, req.Country)
-			continue // Skip this processor
-		}
+		rate := s.cachedApprovalRate(ctx, cache, processor, req.Country)
+
+		// Manual overrides pin the circuit, so automatic transitions only apply without one
+		if !circuits.pinned(ctx, processor, req.Country) {
+			// Check if circuit should be opened
+			if rate > 0 && rate < s.config.CircuitBreakerThreshold {
+				circuits.open(ctx, processor, req.Country, circuitState, rate)
+				excluded = append(excluded, models.ExcludedProcessor{
+					Processor:    processor,
+					Reason:       models.ExclusionLowApprovalRate,
+					Detail:       fmt.Sprintf("approval rate %.2f%% below circuit breaker threshold %.0f%%", rate, s.config.CircuitBreakerThreshold),
+					ApprovalRate: &rate,
+					CircuitState: models.CircuitOpen,
+				})
+				continue // Skip this processor
+			}
 
-		// If circuit is half-open and rate is good, close it
-		if circuitState == models.CircuitHalfOpen && rate >= s.config.CircuitBreakerThreshold {
-			s.store.CloseCircuit(processor, req.Country)
+			// If circuit is half-open and rate is good, close it
+			if circuitState == models.CircuitHalfOpen && rate >= s.config.CircuitBreakerThreshold {
+				circuits.close(ctx, processor, req.Country, circuitState, rate)
+				circuitState = models.CircuitClosed
+			}
 		}
 
-		rates = append(rates, processorRate{name: processor, rate: rate})
+		rates = append(rates, processorRate{name: processor, rate: rate, state: circuitState})
 	}
 
 	// Sort processors by approval rate (descending)
@@ -104,9 +294,18 @@ func (s *RoutingService) selectProcessor(req models.RoutingRequest, simulate boo
 		}
 	}
 
-	// If all processors have 0% rate or all circuits are open, return error
-	if len(rates) == 0 || rates[0].rate == 0.0 {
-		return nil, errors.New("no processor data available for country " + req.Country)
+	// Every processor excluded by its breaker, or no processor with data in the window
+	var err error
+	if len(rates) == 0 {
+		err = newRoutingError(ErrAllCircuitsOpen, "all processor circuits are open for country %s", req.Country)
+	} else if rates[0].rate == 0.0 {
+		err = newRoutingError(ErrNoData, "no processor data available for country %s", req.Country)
+	}
+	if err != nil {
+		if record {
+			s.updateCountryStatus(req.Country, models.CountryStatusUnavailable, "", 0, err.Error())
+		}
+		return nil, nil, err
 	}
 
 	bestProcessor := rates[0].name
@@ -116,28 +315,75 @@ func (s *RoutingService) selectProcessor(req models.RoutingRequest, simulate boo
 	riskLevel := s.classifyRiskLevel(bestRate)
 
 	// Record the routing decision (only if not in simulation mode)
-	if !simulate {
+	decisionID := ""
+	if record {
+		candidates := make([]models.CandidateScore, 0, len(rates))
+		for _, candidate := range rates {
+			candidates = append(candidates, models.CandidateScore{
+				Processor:    candidate.name,
+				ApprovalRate: candidate.rate,
+				CircuitState: candidate.state,
+			})
+		}
+
 		decision := models.RoutingDecision{
-			Processor:    bestProcessor,
-			Country:      req.Country,
-			ApprovalRate: bestRate,
-			Timestamp:    time.Now().Format(time.RFC3339),
+			ID:            uuid.New().String(),
+			Tenant:        s.tenant,
+			Processor:     bestProcessor,
+			Country:       req.Country,
+			ApprovalRate:  bestRate,
+			Timestamp:     s.clock.Now().Format(time.RFC3339),
+			Amount:        req.Amount,
+			Currency:      req.Currency,
+			RiskLevel:     riskLevel,
+			Strategy:      models.StrategyHighestApprovalRate,
+			ConfigVersion: s.config.Version(),
+			Candidates:    candidates,
+			Excluded:      excluded,
 		}
+		decisionID = decision.ID
+		storeSpan := startStoreSpan(ctx, "RecordRoutingDecision", bestProcessor, req.Country)
 		s.store.RecordRoutingDecision(decision)
+		storeSpan.End()
+		if s.recordMetrics {
+			metrics.RecordRoutingDecision(s.tenant, req.Country, bestProcessor, riskLevel)
+		}
+
+		if riskLevel == "high" {
+			s.updateCountryStatus(req.Country, models.CountryStatusHighRisk, bestProcessor, bestRate,
+				fmt.Sprintf("best approval rate %.2f%% is below %.0f%%", bestRate, s.config.HighRiskThreshold))
+		} else {
+			s.updateCountryStatus(req.Country, models.CountryStatusAvailable, bestProcessor, bestRate, "best approval rate is back above the high risk threshold")
+		}
+
+		logger.FromContext(ctx).Info("routing decision",
+			"event", "routing_decision",
+			"decision_id", decision.ID,
+			"tenant", s.tenant,
+			"country", req.Country,
+			"currency", req.Currency,
+			"amount", req.Amount,
+			"processor", bestProcessor,
+			"approval_rate", bestRate,
+			"risk_level", riskLevel,
+			"candidates", len(rates),
+			"excluded", len(excluded),
+		)
 	}
 
 	// Build response
 	reason := fmt.Sprintf("Highest approval rate for %s", req.Country)
 	if riskLevel == "high" {
-		reason = fmt.Sprintf("Best available processor for %s (all processors below 70%%)", req.Country)
+		reason = fmt.Sprintf("Best available processor for %s (all processors below %.0f%%)", req.Country, s.config.HighRiskThreshold)
 	}
 
 	response := &models.RoutingResponse{
+		DecisionID:   decisionID,
 		Processor:    bestProcessor,
 		ApprovalRate: bestRate,
 		RiskLevel:    riskLevel,
 		Reason:       reason,
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    s.clock.Now().Format(time.RFC3339),
 	}
 
 	// Add failover options if requested and available
@@ -156,7 +402,7 @@ func (s *RoutingService) selectProcessor(req models.RoutingRequest, simulate boo
 		}
 	}
 
-	return response, nil
+	return response, rates, nil
 }
 
 // classifyRiskLevel determines the risk level based on approval rate
@@ -170,11 +416,11 @@ func (s *RoutingService) classifyRiskLevel(approvalRate float64) string {
 	return "low"
 }
 
-// GetAllProcessorStats returns stats for all processors across all countries
+// GetAllProcessorStats returns stats for all processors of the tenant across all countries
 func (s *RoutingService) GetAllProcessorStats() []models.ProcessorStats {
 	stats := make([]models.ProcessorStats, 0)
 
-	for country, processors := range config.ProcessorsByCountry {
+	for country, processors := range s.processors() {
 		for _, processor := range processors {
 			stat := s.getProcessorStat(processor, country)
 			stats = append(stats, stat)
@@ -184,10 +430,10 @@ func (s *RoutingService) GetAllProcessorStats() []models.ProcessorStats {
 	return stats
 }
 
-// GetProcessorStats returns stats for a specific processor
+// GetProcessorStats returns stats for a specific processor of the tenant
 func (s *RoutingService) GetProcessorStats(name string) (*models.ProcessorStats, error) {
 	// Find which country this processor belongs to
-	for country, processors := range config.ProcessorsByCountry {
+	for country, processors := range s.processors() {
 		for _, processor := range processors {
 			if processor == name {
 				stat := s.getProcessorStat(processor, country)
@@ -201,40 +447,177 @@ func (s *RoutingService) GetProcessorStats(name string) (*models.ProcessorStats,
 
 // getProcessorStat calculates stats for a single processor
 func (s *RoutingService) getProcessorStat(processor, country string) models.ProcessorStats {
-	transactions := s.store.GetTransactionsByWindow(processor, country, s.config.TimeWindow)
+	transactions := s.store.GetTransactionsByWindow(s.tenant, processor, country, s.config.TimeWindow)
 	approvalRate := s.CalculateApprovalRate(processor, country)
 
 	// Get circuit breaker state
-	circuitState := s.store.GetCircuitState(processor, country, s.config.CircuitBreakerTimeout)
+	s.syncCircuit(processor, country)
+	circuitState := s.store.GetCircuitState(s.tenant, processor, country, s.config.CircuitBreakerTimeout)
 
 	stat := models.ProcessorStats{
 		Name:             processor,
+		Tenant:           s.tenant,
 		Country:          country,
 		ApprovalRate:     approvalRate,
 		TransactionCount: len(transactions),
-		LastUpdated:      time.Now().Format(time.RFC3339),
+		LastUpdated:      s.clock.Now().Format(time.RFC3339),
 	}
 
 	// Add circuit breaker info if not closed
 	if circuitState != models.CircuitClosed {
 		stat.CircuitState = circuitState
-		if openedAt := s.store.GetCircuitOpenedAt(processor, country); openedAt != nil {
+		if openedAt := s.store.GetCircuitOpenedAt(s.tenant, processor, country); openedAt != nil {
 			stat.CircuitOpenedAt = openedAt.Format(time.RFC3339)
 		}
 	}
 
+	// Add manual override info so operators can see who pinned the circuit and why
+	if override := s.store.GetCircuitOverride(s.tenant, processor, country); override != nil {
+		stat.CircuitState = override.State
+		stat.CircuitOverride = &models.CircuitOverride{
+			State:     override.State,
+			Reason:    override.Reason,
+			Operator:  override.Operator,
+			CreatedAt: override.CreatedAt.Format(time.RFC3339),
+			ExpiresAt: override.ExpiresAt.Format(time.RFC3339),
+		}
+	}
+
 	return stat
 }
 
-// GetRoutingStats returns routing decision statistics
-func (s *RoutingService) GetRoutingStats() models.RoutingStats {
-	limit := 50 // Last 50 decisions
-	distribution := s.store.GetRoutingStats(limit)
-	totalDecisions := s.store.GetRoutingDecisionCount()
+// HasProcessor reports whether a processor is configured for a country of the tenant
+func (s *RoutingService) HasProcessor(processor, country string) bool {
+	for _, candidate := range s.processors()[country] {
+		if candidate == processor {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideCircuit manually pins the circuit breaker of a processor to a state until expiresAt
+func (s *RoutingService) OverrideCircuit(processor, country string, state models.CircuitState, reason, operator string, expiresAt time.Time) (*models.ProcessorStats, error) {
+	if !s.HasProcessor(processor, country) {
+		return nil, fmt.Errorf("processor %s not found for country %s", processor, country)
+	}
+
+	switch state {
+	case models.CircuitOpen, models.CircuitClosed, models.CircuitHalfOpen:
+	default:
+		return nil, fmt.Errorf("invalid circuit state %q", state)
+	}
+
+	now := s.clock.Now()
+	if !expiresAt.After(now) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+
+	s.syncCircuit(processor, country)
+	fromState := s.store.GetCircuitState(s.tenant, processor, country, s.config.CircuitBreakerTimeout)
+
+	// A forced close clears the automatic state so the false positive does not resurface on expiry
+	if state == models.CircuitClosed {
+		s.store.CloseCircuit(s.tenant, processor, country)
+	}
+
+	s.store.SetCircuitOverride(s.tenant, processor, country, storage.CircuitOverrideInfo{
+		State:     state,
+		Reason:    reason,
+		Operator:  operator,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	})
+
+	s.recordCircuitEvent(models.CircuitEvent{
+		Tenant:       s.tenant,
+		Processor:    processor,
+		Country:      country,
+		FromState:    fromState,
+		ToState:      state,
+		ApprovalRate: s.CalculateApprovalRate(processor, country),
+		Trigger:      models.CircuitTriggerManualOverride,
+		Operator:     operator,
+		Reason:       reason,
+		Timestamp:    now,
+	})
+
+	stat := s.getProcessorStat(processor, country)
+	return &stat, nil
+}
+
+// ReleaseCircuitOverride removes a manual override and returns the circuit to automatic control
+func (s *RoutingService) ReleaseCircuitOverride(processor, country string) (*models.ProcessorStats, error) {
+	if !s.HasProcessor(processor, country) {
+		return nil, fmt.Errorf("processor %s not found for country %s", processor, country)
+	}
+
+	s.syncCircuit(processor, country)
+	override := s.store.GetCircuitOverride(s.tenant, processor, country)
+	if override == nil || !s.store.ClearCircuitOverride(s.tenant, processor, country) {
+		return nil, fmt.Errorf("no active override for %s in %s", processor, country)
+	}
+
+	s.recordCircuitEvent(models.CircuitEvent{
+		Tenant:       s.tenant,
+		Processor:    processor,
+		Country:      country,
+		FromState:    override.State,
+		ToState:      s.store.GetCircuitState(s.tenant, processor, country, s.config.CircuitBreakerTimeout),
+		ApprovalRate: s.CalculateApprovalRate(processor, country),
+		Trigger:      models.CircuitTriggerOverrideReleased,
+		Timestamp:    s.clock.Now(),
+	})
+
+	stat := s.getProcessorStat(processor, country)
+	return &stat, nil
+}
+
+// maxDecisionPageSize caps the number of decisions returned in one page of the audit log
+const maxDecisionPageSize = 500
 
-	return models.RoutingStats{
-		TotalDecisions: totalDecisions,
-		Distribution:   distribution,
-		Window:         "last_50_decisions",
+// GetRoutingDecisions returns a page of the tenant's routing decision audit log, newest first
+func (s *RoutingService) GetRoutingDecisions(processor, country string, from, to time.Time, offset, limit int) (*models.RoutingDecisionsResponse, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	if limit < 1 || limit > maxDecisionPageSize {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxDecisionPageSize)
 	}
+
+	decisions, total := s.store.GetRoutingDecisions(s.tenant, processor, country, from, to, offset, limit)
+
+	response := &models.RoutingDecisionsResponse{
+		Decisions: decisions,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if next := offset + len(decisions); next < total {
+		response.NextOffset = &next
+	}
+
+	return response, nil
+}
+
+// GetRoutingDecision returns a single routing decision from the tenant's audit log
+// Decisions of other tenants are reported as not found
+func (s *RoutingService) GetRoutingDecision(id string) (*models.RoutingDecision, error) {
+	decision := s.store.GetRoutingDecision(id)
+	if decision == nil || decision.Tenant != s.tenant {
+		return nil, fmt.Errorf("routing decision %s not found", id)
+	}
+	return decision, nil
+}
+
+// GetCircuitHistory returns the tenant's circuit breaker transitions filtered by processor, country and time range
+func (s *RoutingService) GetCircuitHistory(processor, country string, from, to time.Time) ([]models.CircuitEvent, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, errors.New("to must not be before from")
+	}
+
+	return s.store.GetCircuitEvents(s.tenant, processor, country, from, to), nil
 }
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+)
+
+// defaultStatsLimit is the number of most recent decisions covered when no time range is given
+const defaultStatsLimit = 50
+
+// maxStatsBuckets caps the number of time-series buckets in one response
+const maxStatsBuckets = 1000
+
+// targetStatsBuckets is the bucket count an automatically chosen bucket width aims for
+const targetStatsBuckets = 120
+
+// statsBucketWidths are the widths considered when no bucket is requested, narrowest first
+var statsBucketWidths = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+// GetRoutingStats returns routing decision statistics for the decisions selected by query
+// Without a time range or limit, the tenant's most recent 50 decisions across all countries are covered
+func (s *RoutingService) GetRoutingStats(query models.RoutingStatsQuery) (*models.RoutingStats, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, errors.New("to must not be before from")
+	}
+	if query.Limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	if query.Bucket < 0 || (query.Bucket > 0 && query.Bucket < time.Second) {
+		return nil, errors.New("bucket must be at least 1s")
+	}
+	if query.Limit == 0 && query.From.IsZero() && query.To.IsZero() {
+		query.Limit = defaultStatsLimit
+	}
+
+	// Without a time range only the most recent decisions are read, not the whole log
+	var entries []storage.DecisionOutcome
+	if query.From.IsZero() && query.To.IsZero() {
+		entries = s.store.GetRecentDecisionOutcomes(s.tenant, query.Country, query.Limit)
+	} else {
+		entries = s.store.GetDecisionOutcomes(s.tenant, query.Country, query.From, query.To)
+		if query.Limit > 0 && len(entries) > query.Limit {
+			entries = entries[len(entries)-query.Limit:]
+		}
+	}
+
+	stats := &models.RoutingStats{
+		Tenant:          s.tenant,
+		TotalDecisions:  s.store.CountRoutingDecisions(s.tenant),
+		Distribution:    make(map[string]int),
+		Window:          statsWindow(query),
+		Decisions:       len(entries),
+		Share:           make(map[string]float64),
+		RiskLevels:      make(map[string]int),
+		AvgApprovalRate: make(map[string]float64),
+		TimeSeries:      make([]models.RoutingStatsBucket, 0),
+	}
+
+	// Decisions with a parseable timestamp, in order, for the time series
+	timed := make([]timedDecision, 0, len(entries))
+	for _, entry := range entries {
+		decision := entry.Decision
+		stats.Distribution[decision.Processor]++
+		stats.AvgApprovalRate[decision.Processor] += decision.ApprovalRate
+		if decision.RiskLevel != "" {
+			stats.RiskLevels[decision.RiskLevel]++
+		}
+
+		if len(entry.Outcomes) > 0 {
+			stats.Fallback.DecisionsWithOutcome++
+			if usedFallback(entry) {
+				stats.Fallback.FallbackUsed++
+			}
+		}
+
+		if timestamp, err := time.Parse(time.RFC3339, decision.Timestamp); err == nil {
+			timed = append(timed, timedDecision{timestamp: timestamp, processor: decision.Processor})
+		}
+	}
+
+	for processor, count := range stats.Distribution {
+		stats.Share[processor] = (float64(count) / float64(len(entries))) * 100.0
+		stats.AvgApprovalRate[processor] /= float64(count)
+	}
+	if stats.Fallback.DecisionsWithOutcome > 0 {
+		stats.Fallback.Rate = (float64(stats.Fallback.FallbackUsed) / float64(stats.Fallback.DecisionsWithOutcome)) * 100.0
+	}
+
+	if len(timed) == 0 {
+		return stats, nil
+	}
+
+	// Decisions are stored in order, so the first and last timestamps bound the series
+	from, to := timed[0].timestamp, timed[len(timed)-1].timestamp
+	if !query.From.IsZero() {
+		from = query.From
+	}
+	if !query.To.IsZero() {
+		to = query.To
+	}
+	stats.From = &from
+	stats.To = &to
+
+	bucket := query.Bucket
+	if bucket == 0 {
+		bucket = pickBucketWidth(to.Sub(from))
+	}
+	start := from.Truncate(bucket)
+	count := int(to.Sub(start)/bucket) + 1
+	if count > maxStatsBuckets {
+		return nil, fmt.Errorf("bucket %s yields %d buckets, at most %d are allowed", bucket, count, maxStatsBuckets)
+	}
+
+	stats.Bucket = bucket.String()
+	stats.TimeSeries = make([]models.RoutingStatsBucket, count)
+	for i := range stats.TimeSeries {
+		stats.TimeSeries[i] = models.RoutingStatsBucket{
+			Start:        start.Add(time.Duration(i) * bucket),
+			Distribution: make(map[string]int),
+		}
+	}
+	for _, decision := range timed {
+		index := int(decision.timestamp.Sub(start) / bucket)
+		if index < 0 || index >= count {
+			continue
+		}
+		stats.TimeSeries[index].Decisions++
+		stats.TimeSeries[index].Distribution[decision.processor]++
+	}
+
+	return stats, nil
+}
+
+// timedDecision is the part of a decision the time series needs
+type timedDecision struct {
+	timestamp time.Time
+	processor string
+}
+
+// statsWindow describes the decisions covered by a stats query
+func statsWindow(query models.RoutingStatsQuery) string {
+	if query.Limit > 0 {
+		return fmt.Sprintf("last_%d_decisions", query.Limit)
+	}
+	return "time_range"
+}
+
+// pickBucketWidth returns the narrowest standard width that keeps the series near the target bucket count
+func pickBucketWidth(span time.Duration) time.Duration {
+	for _, width := range statsBucketWidths {
+		if span/width < targetStatsBuckets {
+			return width
+		}
+	}
+	return statsBucketWidths[len(statsBucketWidths)-1]
+}
+
+// usedFallback reports whether any outcome of a decision was on a processor other than the routed one
+func usedFallback(entry storage.DecisionOutcome) bool {
+	for _, outcome := range entry.Outcomes {
+		if outcome.Processor != entry.Decision.Processor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/telemetry"
+)
+
+// startStoreSpan starts a span around a store call for a processor in a country
+func startStoreSpan(ctx context.Context, operation, processor, country string) telemetry.Span {
+	_, span := telemetry.StartSpan(ctx, "store."+operation)
+	span.SetAttribute("processor", processor)
+	span.SetAttribute("country", country)
+	return span
+}
+
+// circuitState reads the breaker state of a processor from the store
+func (s *RoutingService) circuitState(ctx context.Context, processor, country string) models.CircuitState {
+	span := startStoreSpan(ctx, "GetCircuitState", processor, country)
+	defer span.End()
+
+	state := s.store.GetCircuitState(s.tenant, processor, country, s.config.CircuitBreakerTimeout)
+	span.SetAttribute("circuit_state", string(state))
+	return state
+}
+
+// circuitPinned reports whether a manual override currently pins the breaker of a processor
+func (s *RoutingService) circuitPinned(ctx context.Context, processor, country string) bool {
+	span := startStoreSpan(ctx, "GetCircuitOverride", processor, country)
+	defer span.End()
+
+	return s.store.GetCircuitOverride(s.tenant, processor, country) != nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/telemetry"
+)
+
+// WhatIf routes requests against current store data using a hypothetical configuration
+// Requests are evaluated in order against a shared simulated breaker view, so a circuit
+// opened by one request is seen by the next; neither the store nor production config change
+func (s *RoutingService) WhatIf(ctx context.Context, override models.RoutingConfigOverride, reqs []models.RoutingRequest) (*models.WhatIfResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "routing.what_if")
+	defer span.End()
+	span.SetAttribute("requests", len(reqs))
+
+	cfg, err := applyConfigOverride(*s.config, override)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// A copy of the service that differs only in its configuration; its breaker view is simulated
+	hypothetical := *s
+	hypothetical.config = cfg
+	circuits := newSimulatedCircuits(&hypothetical)
+
+	results := make([]models.WhatIfResult, 0, len(reqs))
+	for _, req := range reqs {
+		result := models.WhatIfResult{Request: req}
+		transitionsBefore := len(circuits.transitions)
+
+		response, rates, err := hypothetical.route(ctx, req, circuits, nil, false, true)
+		if err != nil {
+			result.Error = &models.ErrorResponse{
+				Error:   "routing_failed",
+				Message: err.Error(),
+			}
+			result.Err = err
+		} else {
+			result.Decision = response
+			result.Rankings = make([]models.ProcessorOption, 0, len(rates))
+			for _, candidate := range rates {
+				result.Rankings = append(result.Rankings, models.ProcessorOption{
+					Processor:    candidate.name,
+					ApprovalRate: candidate.rate,
+				})
+			}
+		}
+
+		result.CircuitTransitions = circuits.transitions[transitionsBefore:]
+		results = append(results, result)
+	}
+
+	return &models.WhatIfResponse{
+		Config: models.EffectiveRoutingConfig{
+			TimeWindow:              cfg.TimeWindow.String(),
+			HighRiskThreshold:       cfg.HighRiskThreshold,
+			MediumRiskThreshold:     cfg.MediumRiskThreshold,
+			CircuitBreakerThreshold: cfg.CircuitBreakerThreshold,
+			CircuitBreakerTimeout:   cfg.CircuitBreakerTimeout.String(),
+		},
+		Results: results,
+	}, nil
+}
+
+// applyConfigOverride returns a copy of cfg with the override applied and validated
+func applyConfigOverride(cfg config.RoutingConfig, override models.RoutingConfigOverride) (*config.RoutingConfig, error) {
+	if override.TimeWindow != "" {
+		window, err := time.ParseDuration(override.TimeWindow)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid time_window %q", override.TimeWindow)
+		}
+		cfg.TimeWindow = window
+	}
+
+	if override.CircuitBreakerTimeout != "" {
+		timeout, err := time.ParseDuration(override.CircuitBreakerTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid circuit_breaker_timeout %q", override.CircuitBreakerTimeout)
+		}
+		cfg.CircuitBreakerTimeout = timeout
+	}
+
+	if override.HighRiskThreshold != nil {
+		cfg.HighRiskThreshold = *override.HighRiskThreshold
+	}
+	if override.MediumRiskThreshold != nil {
+		cfg.MediumRiskThreshold = *override.MediumRiskThreshold
+	}
+	if override.CircuitBreakerThreshold != nil {
+		cfg.CircuitBreakerThreshold = *override.CircuitBreakerThreshold
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
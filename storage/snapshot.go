@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+)
+
+// SnapshotVersion is the format version of snapshots written by this build; Restore rejects other versions
+const SnapshotVersion = 1
+
+// ErrSnapshotExists is returned by WriteSnapshot when a snapshot of that name exists and may not be replaced
+var ErrSnapshotExists = errors.New("snapshot already exists")
+
+// Snapshot is a point-in-time copy of everything held by an InMemoryStore
+type Snapshot struct {
+	Version          int                            `json:"version"`
+	CreatedAt        time.Time                      `json:"created_at"`
+	Transactions     []models.Transaction           `json:"transactions"`
+	RoutingDecisions []models.RoutingDecision       `json:"routing_decisions"`
+	CircuitBreakers  map[string]CircuitBreakerInfo  `json:"circuit_breakers"`  // key: "tenant:processor:country"
+	CircuitOverrides map[string]CircuitOverrideInfo `json:"circuit_overrides"` // key: "tenant:processor:country"
+	CircuitEvents    []models.CircuitEvent          `json:"circuit_events"`
+}
+
+// Snapshot copies the full contents of the store
+func (s *InMemoryStore) Snapshot() *Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := &Snapshot{
+		Version:          SnapshotVersion,
+		CreatedAt:        s.clock.Now(),
+		Transactions:     append([]models.Transaction(nil), s.transactions...),
+		RoutingDecisions: append([]models.RoutingDecision(nil), s.routingDecisions...),
+		CircuitBreakers:  make(map[string]CircuitBreakerInfo, len(s.circuitBreakers)),
+		CircuitOverrides: make(map[string]CircuitOverrideInfo, len(s.circuitOverrides)),
+		CircuitEvents:    append([]models.CircuitEvent(nil), s.circuitEvents...),
+	}
+	for key, info := range s.circuitBreakers {
+		snapshot.CircuitBreakers[key] = *info
+	}
+	for key, override := range s.circuitOverrides {
+		snapshot.CircuitOverrides[key] = *override
+	}
+
+	return snapshot
+}
+
+// Restore replaces the full contents of the store with a snapshot
+// Restored transactions are not counted as ingested again
+func (s *InMemoryStore) Restore(snapshot *Snapshot) error {
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, SnapshotVersion)
+	}
+
+	transactions := make([]models.Transaction, 0, len(snapshot.Transactions))
+	decisionOutcomes := make(map[string][]models.Transaction)
+	for _, tx := range snapshot.Transactions {
+		tx.Tenant = config.TenantOrDefault(tx.Tenant)
+		transactions = append(transactions, tx)
+		if tx.DecisionID != "" {
+			decisionOutcomes[tx.DecisionID] = append(decisionOutcomes[tx.DecisionID], tx)
+		}
+	}
+
+	decisions := make([]models.RoutingDecision, 0, len(snapshot.RoutingDecisions))
+	decisionIndex := make(map[string]int, len(snapshot.RoutingDecisions))
+	for _, decision := range snapshot.RoutingDecisions {
+		decision.Tenant = config.TenantOrDefault(decision.Tenant)
+		if decision.ID != "" {
+			decisionIndex[decision.ID] = len(decisions)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	circuitBreakers := make(map[string]*CircuitBreakerInfo, len(snapshot.CircuitBreakers))
+	for key, info := range snapshot.CircuitBreakers {
+		circuitBreakers[key] = &info
+	}
+	circuitOverrides := make(map[string]*CircuitOverrideInfo, len(snapshot.CircuitOverrides))
+	for key, override := range snapshot.CircuitOverrides {
+		circuitOverrides[key] = &override
+	}
+
+	events := make([]models.CircuitEvent, 0, len(snapshot.CircuitEvents))
+	for _, event := range snapshot.CircuitEvents {
+		event.Tenant = config.TenantOrDefault(event.Tenant)
+		events = append(events, event)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions = transactions
+	s.routingDecisions = decisions
+	s.decisionIndex = decisionIndex
+	s.decisionOutcomes = decisionOutcomes
+	s.circuitBreakers = circuitBreakers
+	s.circuitOverrides = circuitOverrides
+	s.circuitEvents = events
+
+	return nil
+}
+
+// WriteSnapshot writes a snapshot as JSON to path, creating its directory; an existing file is only
+// replaced when overwrite is set, and ErrSnapshotExists is returned otherwise
+// The file is written next to its destination and moved into place, so a failed write never leaves a partial snapshot
+func WriteSnapshot(path string, snapshot *Snapshot, overwrite bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := json.NewEncoder(file).Encode(snapshot); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if overwrite {
+		return os.Rename(file.Name(), path)
+	}
+	// Linking fails if the destination exists, so a concurrent write of the same name cannot be replaced either
+	if err := os.Link(file.Name(), path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrSnapshotExists
+		}
+		return err
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", filepath.Base(path), err)
+	}
+	return &snapshot, nil
+}
@@ -3,56 +3,134 @@ package storage
 import (
 	"sync"
 	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
 	"voltarides/smart-router/models"
 )
 
 // CircuitBreakerInfo holds circuit breaker state for a processor
 type CircuitBreakerInfo struct {
-	State     models.CircuitState
-	OpenedAt  time.Time
+	State    models.CircuitState `json:"state"`
+	OpenedAt time.Time           `json:"opened_at"`
+}
+
+// CircuitOverrideInfo holds a manual circuit breaker override set by an operator
+type CircuitOverrideInfo struct {
+	State     models.CircuitState `json:"state"`
+	Reason    string              `json:"reason"`
+	Operator  string              `json:"operator"`
+	CreatedAt time.Time           `json:"created_at"`
+	ExpiresAt time.Time           `json:"expires_at"`
+}
+
+// DecisionOutcome is a routing decision with the transactions linked to it by decision ID
+type DecisionOutcome struct {
+	Decision models.RoutingDecision
+	Outcomes []models.Transaction
 }
 
 // InMemoryStore provides thread-safe in-memory storage for transactions and routing decisions
+// Reads are scoped by tenant; transactions and decisions without a tenant belong to config.DefaultTenant
 type InMemoryStore struct {
 	transactions     []models.Transaction
 	routingDecisions []models.RoutingDecision
-	circuitBreakers  map[string]*CircuitBreakerInfo // key: "processor:country"
+	decisionIndex    map[string]int                  // decision ID -> position in routingDecisions
+	decisionOutcomes map[string][]models.Transaction // decision ID -> transactions linked to it
+	circuitBreakers  map[string]*CircuitBreakerInfo  // key: "tenant:processor:country"
+	circuitOverrides map[string]*CircuitOverrideInfo // key: "tenant:processor:country"
+	circuitEvents    []models.CircuitEvent           // append-only transition history
+	clock            clock.Clock
 	mu               sync.RWMutex
 }
 
+// StoreOption configures an InMemoryStore
+type StoreOption func(*InMemoryStore)
+
+// WithClock sets the clock used for time windows and circuit breaker timeouts
+func WithClock(clk clock.Clock) StoreOption {
+	return func(s *InMemoryStore) {
+		s.clock = clk
+	}
+}
+
 // NewInMemoryStore creates a new in-memory store
-func NewInMemoryStore() *InMemoryStore {
-	return &InMemoryStore{
+func NewInMemoryStore(opts ...StoreOption) *InMemoryStore {
+	store := &InMemoryStore{
 		transactions:     make([]models.Transaction, 0),
 		routingDecisions: make([]models.RoutingDecision, 0),
+		decisionIndex:    make(map[string]int),
+		decisionOutcomes: make(map[string][]models.Transaction),
 		circuitBreakers:  make(map[string]*CircuitBreakerInfo),
+		circuitOverrides: make(map[string]*CircuitOverrideInfo),
+		circuitEvents:    make([]models.CircuitEvent, 0),
+		clock:            clock.New(),
 	}
+
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	return store
+}
+
+// Clock returns the clock the store uses for time windows and circuit breaker timeouts
+func (s *InMemoryStore) Clock() clock.Clock {
+	return s.clock
 }
 
 // AddTransaction adds a transaction to the store
 func (s *InMemoryStore) AddTransaction(tx models.Transaction) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	tx.Tenant = config.TenantOrDefault(tx.Tenant)
 	s.transactions = append(s.transactions, tx)
+	s.linkOutcome(tx)
 }
 
 // AddTransactions adds multiple transactions to the store
 func (s *InMemoryStore) AddTransactions(txs []models.Transaction) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.transactions = append(s.transactions, txs...)
+	for _, tx := range txs {
+		tx.Tenant = config.TenantOrDefault(tx.Tenant)
+		s.transactions = append(s.transactions, tx)
+		s.linkOutcome(tx)
+	}
+}
+
+// linkOutcome indexes a transaction under the routing decision it resulted from
+// Callers must hold the write lock
+func (s *InMemoryStore) linkOutcome(tx models.Transaction) {
+	if tx.DecisionID != "" {
+		s.decisionOutcomes[tx.DecisionID] = append(s.decisionOutcomes[tx.DecisionID], tx)
+	}
 }
 
-// GetTransactionsByWindow returns transactions for a specific processor and country within a time window
-func (s *InMemoryStore) GetTransactionsByWindow(processor, country string, window time.Duration) []models.Transaction {
+// GetTransactionsByWindow returns transactions of a tenant for a specific processor and country within a time window
+func (s *InMemoryStore) GetTransactionsByWindow(tenant, processor, country string, window time.Duration) []models.Transaction {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	cutoffTime := time.Now().Add(-window)
+	cutoffTime := s.clock.Now().Add(-window)
 	filtered := make([]models.Transaction, 0)
 
 	for _, tx := range s.transactions {
-		if tx.Processor == processor && tx.Country == country && tx.Timestamp.After(cutoffTime) {
+		if tx.Tenant == tenant && tx.Processor == processor && tx.Country == country && tx.Timestamp.After(cutoffTime) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	return filtered
+}
+
+// GetTransactionsByRange returns transactions of a tenant for a specific processor and country stamped within [from, to]
+func (s *InMemoryStore) GetTransactionsByRange(tenant, processor, country string, from, to time.Time) []models.Transaction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := make([]models.Transaction, 0)
+	for _, tx := range s.transactions {
+		if tx.Tenant == tenant && tx.Processor == processor && tx.Country == country && !tx.Timestamp.Before(from) && !tx.Timestamp.After(to) {
 			filtered = append(filtered, tx)
 		}
 	}
@@ -71,7 +149,7 @@ func (s *InMemoryStore) GetAllTransactions() []models.Transaction {
 	return result
 }
 
-// GetTransactionCount returns the total number of transactions
+// GetTransactionCount returns the total number of transactions across all tenants
 func (s *InMemoryStore) GetTransactionCount() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -82,33 +160,139 @@ func (s *InMemoryStore) GetTransactionCount() int {
 func (s *InMemoryStore) RecordRoutingDecision(decision models.RoutingDecision) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	decision.Tenant = config.TenantOrDefault(decision.Tenant)
+	if decision.ID != "" {
+		s.decisionIndex[decision.ID] = len(s.routingDecisions)
+	}
 	s.routingDecisions = append(s.routingDecisions, decision)
 }
 
-// GetRoutingStats returns the routing decision distribution for the last N decisions
-func (s *InMemoryStore) GetRoutingStats(limit int) map[string]int {
+// GetRecentDecisionOutcomes returns a tenant's last N routing decisions in a country (empty matches
+// every country), oldest first, each with the transactions that were linked to it
+func (s *InMemoryStore) GetRecentDecisionOutcomes(tenant, country string, limit int) []DecisionOutcome {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	distribution := make(map[string]int)
-	start := 0
-	if len(s.routingDecisions) > limit {
-		start = len(s.routingDecisions) - limit
+	results := make([]DecisionOutcome, 0)
+	for i := len(s.routingDecisions) - 1; i >= 0 && len(results) < limit; i-- {
+		decision := s.routingDecisions[i]
+		if decision.Tenant != tenant {
+			continue
+		}
+		if country != "" && decision.Country != country {
+			continue
+		}
+
+		outcomes := make([]models.Transaction, len(s.decisionOutcomes[decision.ID]))
+		copy(outcomes, s.decisionOutcomes[decision.ID])
+		results = append(results, DecisionOutcome{Decision: decision, Outcomes: outcomes})
 	}
 
-	for i := start; i < len(s.routingDecisions); i++ {
-		processor := s.routingDecisions[i].Processor
-		distribution[processor]++
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+	return results
+}
+
+// GetRoutingDecisions returns a page of a tenant's routing decisions, newest first, filtered by processor,
+// country and time range (empty or zero filters match everything), along with the number of matches
+func (s *InMemoryStore) GetRoutingDecisions(tenant, processor, country string, from, to time.Time, offset, limit int) ([]models.RoutingDecision, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	page := make([]models.RoutingDecision, 0)
+	total := 0
+	for i := len(s.routingDecisions) - 1; i >= 0; i-- {
+		decision := s.routingDecisions[i]
+		if decision.Tenant != tenant {
+			continue
+		}
+		if processor != "" && decision.Processor != processor {
+			continue
+		}
+		if country != "" && decision.Country != country {
+			continue
+		}
+		if !decisionInRange(decision, from, to) {
+			continue
+		}
+
+		if total >= offset && len(page) < limit {
+			page = append(page, decision)
+		}
+		total++
+	}
+
+	return page, total
+}
+
+// decisionInRange reports whether a decision was made within [from, to] (zero bounds are open)
+func decisionInRange(decision models.RoutingDecision, from, to time.Time) bool {
+	if from.IsZero() && to.IsZero() {
+		return true
 	}
 
-	return distribution
+	timestamp, err := time.Parse(time.RFC3339, decision.Timestamp)
+	if err != nil {
+		return false
+	}
+
+	return (from.IsZero() || !timestamp.Before(from)) && (to.IsZero() || !timestamp.After(to))
 }
 
-// GetRoutingDecisionCount returns the total number of routing decisions
-func (s *InMemoryStore) GetRoutingDecisionCount() int {
+// GetRoutingDecision returns the routing decision with the given ID, or nil if it does not exist
+// Decision IDs are unique across tenants; callers check the decision's tenant
+func (s *InMemoryStore) GetRoutingDecision(id string) *models.RoutingDecision {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.routingDecisions)
+
+	position, exists := s.decisionIndex[id]
+	if !exists {
+		return nil
+	}
+
+	decision := s.routingDecisions[position]
+	return &decision
+}
+
+// GetDecisionOutcomes returns a tenant's routing decisions in a country and time range (empty or zero
+// filters match everything), oldest first, each with the transactions that were linked to it
+func (s *InMemoryStore) GetDecisionOutcomes(tenant, country string, from, to time.Time) []DecisionOutcome {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]DecisionOutcome, 0)
+	for _, decision := range s.routingDecisions {
+		if decision.Tenant != tenant {
+			continue
+		}
+		if country != "" && decision.Country != country {
+			continue
+		}
+		if !decisionInRange(decision, from, to) {
+			continue
+		}
+
+		outcomes := make([]models.Transaction, len(s.decisionOutcomes[decision.ID]))
+		copy(outcomes, s.decisionOutcomes[decision.ID])
+		results = append(results, DecisionOutcome{Decision: decision, Outcomes: outcomes})
+	}
+
+	return results
+}
+
+// CountRoutingDecisions returns the number of routing decisions made for a tenant
+func (s *InMemoryStore) CountRoutingDecisions(tenant string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, decision := range s.routingDecisions {
+		if decision.Tenant == tenant {
+			count++
+		}
+	}
+	return count
 }
 
 // Clear removes all data from the store (useful for testing)
@@ -117,34 +301,49 @@ func (s *InMemoryStore) Clear() {
 	defer s.mu.Unlock()
 	s.transactions = make([]models.Transaction, 0)
 	s.routingDecisions = make([]models.RoutingDecision, 0)
+	s.decisionIndex = make(map[string]int)
+	s.decisionOutcomes = make(map[string][]models.Transaction)
 	s.circuitBreakers = make(map[string]*CircuitBreakerInfo)
+	s.circuitOverrides = make(map[string]*CircuitOverrideInfo)
+	s.circuitEvents = make([]models.CircuitEvent, 0)
+}
+
+// circuitKey identifies the circuit breaker of a tenant's processor in a country
+// Tenants have separate contracts with a processor, so each has its own breaker
+func circuitKey(tenant, processor, country string) string {
+	return tenant + ":" + processor + ":" + country
 }
 
 // OpenCircuit opens the circuit breaker for a processor
-func (s *InMemoryStore) OpenCircuit(processor, country string) {
+func (s *InMemoryStore) OpenCircuit(tenant, processor, country string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	key := processor + ":" + country
+	key := circuitKey(tenant, processor, country)
 	s.circuitBreakers[key] = &CircuitBreakerInfo{
 		State:    models.CircuitOpen,
-		OpenedAt: time.Now(),
+		OpenedAt: s.clock.Now(),
 	}
 }
 
 // CloseCircuit closes the circuit breaker for a processor
-func (s *InMemoryStore) CloseCircuit(processor, country string) {
+func (s *InMemoryStore) CloseCircuit(tenant, processor, country string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	key := processor + ":" + country
+	key := circuitKey(tenant, processor, country)
 	delete(s.circuitBreakers, key)
 }
 
 // GetCircuitState returns the circuit breaker state for a processor
-func (s *InMemoryStore) GetCircuitState(processor, country string, timeout time.Duration) models.CircuitState {
+// An active manual override takes precedence over the automatic state
+func (s *InMemoryStore) GetCircuitState(tenant, processor, country string, timeout time.Duration) models.CircuitState {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	key := processor + ":" + country
+	key := circuitKey(tenant, processor, country)
+	if override, exists := s.circuitOverrides[key]; exists && s.clock.Now().Before(override.ExpiresAt) {
+		return override.State
+	}
+
 	info, exists := s.circuitBreakers[key]
 
 	if !exists {
@@ -152,19 +351,85 @@ func (s *InMemoryStore) GetCircuitState(processor, country string, timeout time.
 	}
 
 	// Check if circuit should transition to half-open
-	if time.Since(info.OpenedAt) > timeout {
+	if s.clock.Now().Sub(info.OpenedAt) > timeout {
 		return models.CircuitHalfOpen
 	}
 
 	return info.State
 }
 
+// SyncCircuit applies the transitions of a circuit breaker that happen with time rather than on a routing
+// decision, and returns them stamped with when they took effect, oldest first: an expired manual override
+// handing the circuit back to automatic control, and an open breaker turning half-open after the timeout.
+// Each transition is returned once; callers record them
+func (s *InMemoryStore) SyncCircuit(tenant, processor, country string, timeout time.Duration) []models.CircuitEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	key := circuitKey(tenant, processor, country)
+	info := s.circuitBreakers[key]
+	transitions := make([]models.CircuitEvent, 0)
+
+	// halfOpenAt reports when an open breaker turns half-open, if it has by at
+	halfOpenAt := func(at time.Time) (time.Time, bool) {
+		if info == nil || info.State != models.CircuitOpen || at.Sub(info.OpenedAt) <= timeout {
+			return time.Time{}, false
+		}
+		return info.OpenedAt.Add(timeout), true
+	}
+
+	if override, exists := s.circuitOverrides[key]; exists {
+		// An active override masks the automatic state, so its transitions show when the override ends
+		if now.Before(override.ExpiresAt) {
+			return transitions
+		}
+
+		state := models.CircuitClosed
+		if info != nil {
+			state = info.State
+		}
+		if _, ok := halfOpenAt(override.ExpiresAt); ok {
+			state = models.CircuitHalfOpen
+			info.State = models.CircuitHalfOpen
+		}
+
+		delete(s.circuitOverrides, key)
+		transitions = append(transitions, models.CircuitEvent{
+			Tenant:    tenant,
+			Processor: processor,
+			Country:   country,
+			FromState: override.State,
+			ToState:   state,
+			Trigger:   models.CircuitTriggerOverrideExpired,
+			Operator:  override.Operator,
+			Reason:    override.Reason,
+			Timestamp: override.ExpiresAt,
+		})
+	}
+
+	if at, ok := halfOpenAt(now); ok {
+		info.State = models.CircuitHalfOpen
+		transitions = append(transitions, models.CircuitEvent{
+			Tenant:    tenant,
+			Processor: processor,
+			Country:   country,
+			FromState: models.CircuitOpen,
+			ToState:   models.CircuitHalfOpen,
+			Trigger:   models.CircuitTriggerTimeout,
+			Timestamp: at,
+		})
+	}
+
+	return transitions
+}
+
 // GetCircuitOpenedAt returns when the circuit was opened for a processor
-func (s *InMemoryStore) GetCircuitOpenedAt(processor, country string) *time.Time {
+func (s *InMemoryStore) GetCircuitOpenedAt(tenant, processor, country string) *time.Time {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	key := processor + ":" + country
+	key := circuitKey(tenant, processor, country)
 	info, exists := s.circuitBreakers[key]
 
 	if !exists {
@@ -173,3 +438,73 @@ func (s *InMemoryStore) GetCircuitOpenedAt(processor, country string) *time.Time
 
 	return &info.OpenedAt
 }
+
+// SetCircuitOverride pins the circuit breaker of a processor to a state until the override expires
+func (s *InMemoryStore) SetCircuitOverride(tenant, processor, country string, override CircuitOverrideInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := circuitKey(tenant, processor, country)
+	s.circuitOverrides[key] = &override
+}
+
+// ClearCircuitOverride removes a manual override, returning the circuit to automatic control
+func (s *InMemoryStore) ClearCircuitOverride(tenant, processor, country string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := circuitKey(tenant, processor, country)
+	_, exists := s.circuitOverrides[key]
+	delete(s.circuitOverrides, key)
+	return exists
+}
+
+// GetCircuitOverride returns the active manual override for a processor, or nil if none is active
+func (s *InMemoryStore) GetCircuitOverride(tenant, processor, country string) *CircuitOverrideInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := circuitKey(tenant, processor, country)
+	override, exists := s.circuitOverrides[key]
+	if !exists || !s.clock.Now().Before(override.ExpiresAt) {
+		return nil
+	}
+
+	result := *override
+	return &result
+}
+
+// RecordCircuitEvent appends a circuit breaker transition to the history
+func (s *InMemoryStore) RecordCircuitEvent(event models.CircuitEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event.Tenant = config.TenantOrDefault(event.Tenant)
+	s.circuitEvents = append(s.circuitEvents, event)
+}
+
+// GetCircuitEvents returns a tenant's circuit transitions matching the filters in chronological order
+// Empty processor or country and zero times are treated as unbounded
+func (s *InMemoryStore) GetCircuitEvents(tenant, processor, country string, from, to time.Time) []models.CircuitEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filtered := make([]models.CircuitEvent, 0)
+	for _, event := range s.circuitEvents {
+		if event.Tenant != tenant {
+			continue
+		}
+		if processor != "" && event.Processor != processor {
+			continue
+		}
+		if country != "" && event.Country != country {
+			continue
+		}
+		if !from.IsZero() && event.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && event.Timestamp.After(to) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+
+	return filtered
+}
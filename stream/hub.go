@@ -0,0 +1,249 @@
+package stream
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/models"
+)
+
+// subscriberBuffer is the number of messages queued for a subscriber before it is disconnected
+const subscriberBuffer = 64
+
+// Source provides the processor health and routing statistics of one tenant, typically the routing service
+type Source interface {
+	Tenant() string
+	Countries() []string
+	GetAllProcessorStats() []models.ProcessorStats
+	GetRoutingStats(query models.RoutingStatsQuery) (*models.RoutingStats, error)
+}
+
+// Message is one update for stream subscribers
+type Message struct {
+	Type    string      // SSE event name
+	Country string      // Country the update concerns, for filtering
+	Data    interface{} // JSON payload
+}
+
+// Hub fans live processor health updates of one tenant out to stream subscribers
+//
+// Processor stats and routing distribution are polled and only changes are broadcast; router events
+// from the event bus (circuit transitions, country status changes) of the tenant are forwarded as they are published.
+// A subscriber that falls behind is disconnected rather than silently missing updates, so that it
+// reconnects and starts again from a fresh snapshot
+type Hub struct {
+	source   Source
+	interval time.Duration
+
+	subscribers map[int]*subscriber
+	nextID      int
+	mu          sync.Mutex
+
+	lastStats        map[string]models.ProcessorStats // key: "processor:country"
+	lastDistribution map[string]map[string]int        // key: country
+	pollMu           sync.Mutex
+}
+
+// subscriber is one open stream
+type subscriber struct {
+	country  string // Empty for every country
+	messages chan Message
+}
+
+// NewHub creates a hub polling source at the configured interval
+func NewHub(source Source, cfg *config.StreamConfig) *Hub {
+	return &Hub{
+		source:           source,
+		interval:         cfg.PollInterval,
+		subscribers:      make(map[int]*subscriber),
+		lastStats:        make(map[string]models.ProcessorStats),
+		lastDistribution: make(map[string]map[string]int),
+	}
+}
+
+// Tenant returns the tenant whose updates the hub streams
+func (h *Hub) Tenant() string {
+	return h.source.Tenant()
+}
+
+// Countries returns the countries of the hub's tenant in a stable order
+func (h *Hub) Countries() []string {
+	return h.source.Countries()
+}
+
+// Run polls for changes until ctx is cancelled
+func (h *Hub) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if h.subscriberCount() > 0 {
+				h.Poll()
+			}
+		}
+	}
+}
+
+// Subscribe opens a stream for a country (empty for every country)
+// The returned channel is closed when the subscriber is dropped for falling behind or unsubscribes
+func (h *Hub) Subscribe(country string) (<-chan Message, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	sub := &subscriber{country: country, messages: make(chan Message, subscriberBuffer)}
+	h.subscribers[id] = sub
+
+	return sub.messages, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(id)
+	}
+}
+
+// Snapshot returns the current processor stats and routing distribution for a country (empty for every country)
+func (h *Hub) Snapshot(country string) []Message {
+	snapshot := make([]Message, 0)
+	for _, stat := range h.processorStats() {
+		if country == "" || stat.Country == country {
+			snapshot = append(snapshot, Message{Type: models.StreamProcessorStats, Country: stat.Country, Data: stat})
+		}
+	}
+
+	for _, code := range h.source.Countries() {
+		if country != "" && code != country {
+			continue
+		}
+		if update := h.distribution(code); update != nil {
+			snapshot = append(snapshot, Message{Type: models.StreamRoutingDistribution, Country: code, Data: update})
+		}
+	}
+
+	return snapshot
+}
+
+// Poll compares processor stats and routing distribution with the previous poll and broadcasts the changes
+func (h *Hub) Poll() []Message {
+	h.pollMu.Lock()
+	defer h.pollMu.Unlock()
+
+	changes := make([]Message, 0)
+	for _, stat := range h.processorStats() {
+		key := stat.Name + ":" + stat.Country
+		if previous, seen := h.lastStats[key]; !seen || statsChanged(previous, stat) {
+			changes = append(changes, Message{Type: models.StreamProcessorStats, Country: stat.Country, Data: stat})
+		}
+		h.lastStats[key] = stat
+	}
+
+	for _, country := range h.source.Countries() {
+		update := h.distribution(country)
+		if update == nil {
+			continue
+		}
+		if previous, seen := h.lastDistribution[country]; !seen || !reflect.DeepEqual(previous, update.Distribution) {
+			changes = append(changes, Message{Type: models.StreamRoutingDistribution, Country: country, Data: update})
+		}
+		h.lastDistribution[country] = update.Distribution
+	}
+
+	for _, message := range changes {
+		h.broadcast(message)
+	}
+
+	return changes
+}
+
+// Handle forwards a router event of the hub's tenant to subscribers; it is meant to be subscribed to an events.Bus
+func (h *Hub) Handle(event events.Event) {
+	if config.TenantOrDefault(event.Tenant) != h.source.Tenant() {
+		return
+	}
+
+	var country string
+	switch data := event.Data.(type) {
+	case models.CircuitEvent:
+		country = data.Country
+	case models.CountryStatusChange:
+		country = data.Country
+	}
+
+	h.broadcast(Message{Type: event.Type, Country: country, Data: event})
+}
+
+// broadcast queues a message for every subscriber of its country without blocking
+func (h *Hub) broadcast(message Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, sub := range h.subscribers {
+		if sub.country != "" && message.Country != "" && sub.country != message.Country {
+			continue
+		}
+
+		select {
+		case sub.messages <- message:
+		default:
+			h.remove(id)
+		}
+	}
+}
+
+// remove closes and forgets a subscriber; callers must hold h.mu
+func (h *Hub) remove(id int) {
+	if sub, exists := h.subscribers[id]; exists {
+		close(sub.messages)
+		delete(h.subscribers, id)
+	}
+}
+
+// subscriberCount returns the number of open streams
+func (h *Hub) subscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// processorStats returns the current stats of every processor ordered by country and name
+func (h *Hub) processorStats() []models.ProcessorStats {
+	stats := h.source.GetAllProcessorStats()
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Country != stats[j].Country {
+			return stats[i].Country < stats[j].Country
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// distribution returns the routing distribution a country's routing stats report by default
+func (h *Hub) distribution(country string) *models.RoutingDistributionUpdate {
+	stats, err := h.source.GetRoutingStats(models.RoutingStatsQuery{Country: country})
+	if err != nil {
+		return nil
+	}
+
+	return &models.RoutingDistributionUpdate{
+		Country:      country,
+		Decisions:    stats.Decisions,
+		Distribution: stats.Distribution,
+		Share:        stats.Share,
+		Timestamp:    time.Now(),
+	}
+}
+
+// statsChanged reports whether two stats of the same processor differ in anything but their timestamp
+func statsChanged(previous, current models.ProcessorStats) bool {
+	previous.LastUpdated = ""
+	current.LastUpdated = ""
+	return !reflect.DeepEqual(previous, current)
+}
@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"strconv"
+	"voltarides/smart-router/config"
+
+	"github.com/labstack/echo/v4"
+	echoDatadog "gopkg.in/DataDog/dd-trace-go.v1/contrib/labstack/echo.v4"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+)
+
+// datadogTracer exports spans to the DataDog agent (Yuno standard)
+type datadogTracer struct {
+	serviceName string
+}
+
+func newDatadogTracer(cfg *config.TelemetryConfig) *datadogTracer {
+	tracer.Start(
+		tracer.WithService(cfg.ServiceName),
+		tracer.WithEnv(cfg.Environment),
+	)
+	return &datadogTracer{serviceName: cfg.ServiceName}
+}
+
+func (t *datadogTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span, ctx := tracer.StartSpanFromContext(ctx, name)
+	return ctx, &datadogSpan{span: span}
+}
+
+func (t *datadogTracer) SpanFromContext(ctx context.Context) Span {
+	span, exists := tracer.SpanFromContext(ctx)
+	if !exists {
+		return noopSpan{}
+	}
+	return &datadogSpan{span: span}
+}
+
+func (t *datadogTracer) Middleware() echo.MiddlewareFunc {
+	return echoDatadog.Middleware(echoDatadog.WithServiceName(t.serviceName))
+}
+
+func (t *datadogTracer) Shutdown(ctx context.Context) error {
+	tracer.Stop()
+	return nil
+}
+
+// datadogSpan wraps a DataDog span
+type datadogSpan struct {
+	span ddtrace.Span
+}
+
+func (s *datadogSpan) SetAttribute(key string, value interface{}) {
+	s.span.SetTag(key, value)
+}
+
+func (s *datadogSpan) RecordError(err error) {
+	s.span.SetTag(ext.Error, err)
+}
+
+func (s *datadogSpan) TraceID() string {
+	return strconv.FormatUint(s.span.Context().TraceID(), 10)
+}
+
+func (s *datadogSpan) End() {
+	s.span.Finish()
+}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"voltarides/smart-router/config"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otlpTracer exports spans over OTLP/HTTP, typically to a local OpenTelemetry collector
+type otlpTracer struct {
+	serviceName string
+	provider    *sdktrace.TracerProvider
+	tracer      trace.Tracer
+}
+
+func newOTLPTracer(cfg *config.TelemetryConfig) (*otlpTracer, error) {
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OTLPEndpoint)}
+	if cfg.OTLPInsecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.DeploymentEnvironment(cfg.Environment),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return &otlpTracer{
+		serviceName: cfg.ServiceName,
+		provider:    provider,
+		tracer:      provider.Tracer(cfg.ServiceName),
+	}, nil
+}
+
+func (t *otlpTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, &otlpSpan{span: span}
+}
+
+func (t *otlpTracer) SpanFromContext(ctx context.Context) Span {
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return noopSpan{}
+	}
+	return &otlpSpan{span: span}
+}
+
+func (t *otlpTracer) Middleware() echo.MiddlewareFunc {
+	return otelecho.Middleware(t.serviceName, otelecho.WithTracerProvider(t.provider))
+}
+
+func (t *otlpTracer) Shutdown(ctx context.Context) error {
+	return t.provider.Shutdown(ctx)
+}
+
+// otlpSpan wraps an OpenTelemetry span
+type otlpSpan struct {
+	span trace.Span
+}
+
+func (s *otlpSpan) SetAttribute(key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case float64:
+		s.span.SetAttributes(attribute.Float64(key, v))
+	case bool:
+		s.span.SetAttributes(attribute.Bool(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+func (s *otlpSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s *otlpSpan) TraceID() string {
+	return s.span.SpanContext().TraceID().String()
+}
+
+func (s *otlpSpan) End() {
+	s.span.End()
+}
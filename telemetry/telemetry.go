@@ -0,0 +1,67 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"voltarides/smart-router/config"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Span is a unit of traced work
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	TraceID() string
+	End()
+}
+
+// Tracer creates spans for a tracing backend
+type Tracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+	SpanFromContext(ctx context.Context) Span
+	Middleware() echo.MiddlewareFunc
+	Shutdown(ctx context.Context) error
+}
+
+// current is the process-wide tracer, a no-op until Init is called
+var current Tracer = noopTracer{}
+
+// Init starts the tracer selected by cfg.Exporter and makes it the process-wide tracer
+func Init(cfg *config.TelemetryConfig) (Tracer, error) {
+	var tracer Tracer
+	var err error
+
+	switch cfg.Exporter {
+	case "datadog":
+		tracer = newDatadogTracer(cfg)
+	case "otlp":
+		tracer, err = newOTLPTracer(cfg)
+	case "none":
+		tracer = noopTracer{}
+	default:
+		return nil, fmt.Errorf("unknown telemetry exporter %q", cfg.Exporter)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	current = tracer
+	return tracer, nil
+}
+
+// StartSpan starts a span as a child of any span in ctx
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	return current.StartSpan(ctx, name)
+}
+
+// SpanFromContext returns the active span in ctx, or a no-op span if there is none
+func SpanFromContext(ctx context.Context) Span {
+	return current.SpanFromContext(ctx)
+}
+
+// Middleware returns the HTTP tracing middleware of the active tracer
+func Middleware() echo.MiddlewareFunc {
+	return current.Middleware()
+}
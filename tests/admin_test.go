@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/client"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newAdminRouter configures an Echo server with authentication disabled and snapshots written to dir
+func newAdminRouter(store *storage.InMemoryStore, environment, dir string) *echo.Echo {
+	authenticator, _ := auth.NewAuthenticator(&config.SecurityConfig{})
+	snapshots := &config.SnapshotConfig{Dir: dir, ConfirmationTTL: time.Minute}
+	return newDataRouter(store, authenticator, newDisabledLimiter(), controllers.NewDataController(store, environment, snapshots))
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+
+	dir := t.TempDir()
+	server := httptest.NewServer(newAdminRouter(store, "production", dir))
+	defer server.Close()
+
+	router := client.New(server.URL)
+	ctx := context.Background()
+
+	decision, err := router.Route(ctx, models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	snapshot, err := router.CreateSnapshot(ctx, models.SnapshotRequest{Name: "before-reset.json"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if snapshot.Transactions != 20 || snapshot.RoutingDecisions != 1 {
+		t.Errorf("Expected 20 transactions and 1 decision, got %d and %d", snapshot.Transactions, snapshot.RoutingDecisions)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "before-reset.json")); err != nil {
+		t.Errorf("Expected the snapshot file to be written, got %v", err)
+	}
+
+	generated, err := router.CreateSnapshot(ctx, models.SnapshotRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(generated.Name, "snapshot-") {
+		t.Errorf("Expected a generated snapshot name, got %s", generated.Name)
+	}
+
+	cleared, err := router.ClearStore(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cleared.TransactionsCleared != 20 || store.GetTransactionCount() != 0 {
+		t.Errorf("Expected 20 transactions cleared and none left, got %d and %d", cleared.TransactionsCleared, store.GetTransactionCount())
+	}
+
+	restored, err := router.RestoreSnapshot(ctx, "before-reset.json")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restored.Transactions != 20 || store.GetTransactionCount() != 20 {
+		t.Errorf("Expected 20 transactions restored, got %d", store.GetTransactionCount())
+	}
+
+	// Indexes and circuits are rebuilt, so decisions can be looked up and outcomes linked again
+	if _, err := router.RoutingDecision(ctx, decision.DecisionID); err != nil {
+		t.Errorf("Expected the restored decision to be found, got %v", err)
+	}
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", time.Hour); state != models.CircuitOpen {
+		t.Errorf("Expected the restored circuit to be open, got %s", state)
+	}
+
+	var apiErr *client.APIError
+	if _, err := router.RestoreSnapshot(ctx, "missing.json"); !errors.As(err, &apiErr) || apiErr.Code != "snapshot_not_found" {
+		t.Errorf("Expected snapshot_not_found, got %v", err)
+	}
+	if _, err := router.CreateSnapshot(ctx, models.SnapshotRequest{Name: "../outside.json"}); !errors.As(err, &apiErr) || apiErr.Code != "invalid_snapshot" {
+		t.Errorf("Expected invalid_snapshot, got %v", err)
+	}
+
+	// An existing snapshot is only replaced when asked to
+	if _, err := router.CreateSnapshot(ctx, models.SnapshotRequest{Name: "before-reset.json"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || apiErr.Code != "snapshot_exists" {
+		t.Errorf("Expected 409 snapshot_exists, got %v", err)
+	}
+	if kept, err := storage.ReadSnapshot(filepath.Join(dir, "before-reset.json")); err != nil || len(kept.Transactions) != 20 {
+		t.Errorf("Expected the existing snapshot to be kept, got %v", err)
+	}
+	if _, err := router.CreateSnapshot(ctx, models.SnapshotRequest{Name: generated.Name, Overwrite: true}); err != nil {
+		t.Errorf("Expected an explicit overwrite to succeed, got %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte(`{"version": 99}`), 0o644)
+	if _, err := router.RestoreSnapshot(ctx, "corrupt.json"); !errors.As(err, &apiErr) || apiErr.Code != "invalid_snapshot" {
+		t.Errorf("Expected invalid_snapshot, got %v", err)
+	}
+	if store.GetTransactionCount() != 20 {
+		t.Errorf("Expected a rejected snapshot to leave the store untouched, got %d transactions", store.GetTransactionCount())
+	}
+}
+
+func TestClearStoreConfirmation(t *testing.T) {
+	clk := clock.NewSimulated(time.Now())
+	store := storage.NewInMemoryStore(storage.WithClock(clk))
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, clk.Now().Add(-5*time.Minute))
+
+	authenticator, _ := auth.NewAuthenticator(&config.SecurityConfig{
+		AuthEnabled: true,
+		APIKeys: []config.APIKeyConfig{
+			{Client: "root", Key: "admin-key", Roles: []string{auth.RoleAdmin}},
+			{Client: "ops", Key: "ops-key", Roles: []string{auth.RoleAdmin}},
+			{Client: "oncall", Key: "operator-key", Roles: []string{auth.RoleOperator}},
+		},
+	})
+	snapshots := &config.SnapshotConfig{Dir: t.TempDir(), ConfirmationTTL: time.Minute}
+	e := newDataRouter(store, authenticator, newDisabledLimiter(), controllers.NewDataController(store, "production", snapshots))
+
+	clear := func(apiKey, token string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(models.ClearStoreRequest{ConfirmationToken: token})
+		req := httptest.NewRequest(http.MethodPost, "/volta-router/v1/admin/store/clear", strings.NewReader(string(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	issue := func(apiKey string) string {
+		rec := clear(apiKey, "")
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("Expected status 202, got %d (%s)", rec.Code, rec.Body.String())
+		}
+		var confirmation models.ClearStoreConfirmation
+		json.Unmarshal(rec.Body.Bytes(), &confirmation)
+		return confirmation.ConfirmationToken
+	}
+
+	if rec := clear("operator-key", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a non-admin, got %d", rec.Code)
+	}
+	if rec := clear("admin-key", "made-up"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown token, got %d", rec.Code)
+	}
+
+	// Tokens belong to the client they were issued to
+	token := issue("admin-key")
+	if rec := clear("ops-key", token); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for another client's token, got %d", rec.Code)
+	}
+
+	// Tokens expire
+	token = issue("admin-key")
+	clk.Advance(2 * time.Minute)
+	if rec := clear("admin-key", token); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an expired token, got %d", rec.Code)
+	}
+	if store.GetTransactionCount() != 10 {
+		t.Fatalf("Expected the store to be untouched, got %d transactions", store.GetTransactionCount())
+	}
+
+	// Tokens are single use
+	token = issue("admin-key")
+	if rec := clear("admin-key", token); rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	if store.GetTransactionCount() != 0 {
+		t.Errorf("Expected the store to be cleared, got %d transactions", store.GetTransactionCount())
+	}
+	if rec := clear("admin-key", token); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a used token, got %d", rec.Code)
+	}
+}
+
+func TestLoadTestDataOnlyInDevelopment(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, time.Now().Add(-5*time.Minute))
+
+	e := newAdminRouter(store, "production", t.TempDir())
+	req := httptest.NewRequest(http.MethodPost, "/volta-router/v1/transactions/load", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403, got %d", rec.Code)
+	}
+	var response models.ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response.Error != "load_disabled" {
+		t.Errorf("Expected load_disabled, got %s", response.Error)
+	}
+	if store.GetTransactionCount() != 10 {
+		t.Errorf("Expected the store to be untouched, got %d transactions", store.GetTransactionCount())
+	}
+}
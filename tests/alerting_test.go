@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/alerting"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+)
+
+// recordingSink collects the alerts it receives
+type recordingSink struct {
+	alerts []models.Alert
+}
+
+func (s *recordingSink) Send(ctx context.Context, alert models.Alert) error {
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func newTestDetector(start time.Time, sinks ...alerting.Sink) (*alerting.Detector, *storage.InMemoryStore, *clock.Simulated) {
+	simulatedClock := clock.NewSimulated(start)
+	store := storage.NewInMemoryStore(storage.WithClock(simulatedClock))
+	detector := alerting.NewDetector(store, config.GetAnomalyConfig(), sinks...)
+	return detector, store, simulatedClock
+}
+
+func TestDetectorRaisesAndResolvesAlert(t *testing.T) {
+	sink := &recordingSink{}
+	now := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+	detector, store, simulatedClock := newTestDetector(now, sink)
+
+	// Healthy baseline at 90%, then 40% in the last five minutes
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 90, 100, now.Add(-30*time.Minute))
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 12, 30, now.Add(-2*time.Minute))
+
+	changed := detector.Evaluate(context.Background())
+	if len(changed) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(changed))
+	}
+
+	alert := changed[0]
+	if alert.Processor != "RapidPay_BR" || alert.Status != models.AlertStatusActive || alert.Type != models.AlertApprovalRateDrop {
+		t.Errorf("Expected active approval rate drop for RapidPay_BR, got %+v", alert)
+	}
+	if alert.Tenant != config.DefaultTenant || !strings.HasPrefix(alert.Message, "["+config.DefaultTenant+"] ") {
+		t.Errorf("Expected the alert and its message to name tenant %s, got %s: %s", config.DefaultTenant, alert.Tenant, alert.Message)
+	}
+	if alert.ShortRate != 40.0 || alert.BaselineRate != 90.0 || alert.ZScore >= -3.0 {
+		t.Errorf("Expected 40%% against 90%% with z below -3, got %.1f against %.1f (z=%.2f)", alert.ShortRate, alert.BaselineRate, alert.ZScore)
+	}
+	if len(sink.alerts) != 1 {
+		t.Errorf("Expected sink to receive 1 alert, got %d", len(sink.alerts))
+	}
+
+	// A second pass with the same data does not re-emit
+	if changed := detector.Evaluate(context.Background()); len(changed) != 0 {
+		t.Errorf("Expected no changes on re-evaluation, got %d", len(changed))
+	}
+	if active := detector.ActiveAlerts(); len(active) != 1 {
+		t.Errorf("Expected 1 active alert, got %d", len(active))
+	}
+
+	// Five minutes later the processor is healthy again
+	simulatedClock.Advance(5 * time.Minute)
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 27, 30, simulatedClock.Now().Add(-time.Minute))
+
+	changed = detector.Evaluate(context.Background())
+	if len(changed) != 1 || changed[0].Status != models.AlertStatusResolved || changed[0].ResolvedAt == nil {
+		t.Fatalf("Expected the alert to resolve, got %+v", changed)
+	}
+	if len(detector.ActiveAlerts()) != 0 || len(detector.Alerts("", models.AlertStatusResolved)) != 1 {
+		t.Error("Expected the alert to move from active to resolved")
+	}
+}
+
+func TestDetectorIgnoresSmallSamplesAndSmallDrops(t *testing.T) {
+	now := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+	detector, store, _ := newTestDetector(now)
+
+	// Too few recent transactions to judge
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 90, 100, now.Add(-30*time.Minute))
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 0, 5, now.Add(-time.Minute))
+
+	// Significant with a lot of traffic, but only a five-point drop
+	addProcessorTransactions(store, "PayFlow_MX", "MX", 9000, 10000, now.Add(-30*time.Minute))
+	addProcessorTransactions(store, "PayFlow_MX", "MX", 1700, 2000, now.Add(-time.Minute))
+
+	if changed := detector.Evaluate(context.Background()); len(changed) != 0 {
+		t.Errorf("Expected no alerts, got %+v", changed)
+	}
+}
+
+func TestLogSinkLogsTenant(t *testing.T) {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buffer, nil)))
+	defer slog.SetDefault(previous)
+
+	alert := models.Alert{Tenant: "food", Processor: "PayFlow_BR", Country: "BR", Status: models.AlertStatusActive, Message: "[food] PayFlow_BR in BR approval rate dropped"}
+	if err := alerting.NewLogSink().Send(context.Background(), alert); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buffer.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q", buffer.String())
+	}
+	if entry["tenant"] != "food" || entry["msg"] != alert.Message {
+		t.Errorf("Expected the log line to carry tenant food and the alert message, got %v", entry)
+	}
+}
+
+func TestWebhookSinkPostsAlert(t *testing.T) {
+	var received models.Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := alerting.NewWebhookSink(server.URL)
+	if err := sink.Send(context.Background(), models.Alert{ID: "a1", Processor: "RapidPay_BR"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if received.ID != "a1" {
+		t.Errorf("Expected webhook to receive alert a1, got %q", received.ID)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	if err := alerting.NewWebhookSink(failing.URL).Send(context.Background(), models.Alert{ID: "a2"}); err == nil {
+		t.Error("Expected error for non-2xx webhook response")
+	}
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/grpcapi"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var jwtSecret = []byte("test-secret")
+
+// newTestAuthenticator enables authentication with one key per role and HS256 tokens
+func newTestAuthenticator(t *testing.T) *auth.Authenticator {
+	t.Helper()
+
+	authenticator, err := auth.NewAuthenticator(&config.SecurityConfig{
+		AuthEnabled: true,
+		APIKeys: []config.APIKeyConfig{
+			{Client: "checkout", Key: "router-key", Roles: []string{auth.RoleRouterClient}},
+			{Client: "ledger", Key: "ingest-key", Roles: []string{auth.RoleIngestWriter}},
+			{Client: "oncall", Key: "operator-key", Roles: []string{auth.RoleOperator}},
+			{Client: "root", Key: "admin-key", Roles: []string{auth.RoleAdmin}},
+		},
+		JWTSecret: string(jwtSecret),
+		JWTIssuer: "volta",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return authenticator
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	e := newAuthRouter(storage.NewInMemoryStore(), newTestAuthenticator(t), newDisabledLimiter())
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		apiKey string
+		status int
+		code   string
+	}{
+		{"health is public", http.MethodGet, "/health", "", http.StatusOK, ""},
+		{"error catalog is public", http.MethodGet, "/volta-router/v1/errors", "", http.StatusOK, ""},
+		{"missing key", http.MethodGet, "/volta-router/v1/processors", "", http.StatusUnauthorized, "unauthorized"},
+		{"unknown key", http.MethodGet, "/health", "wrong-key", http.StatusUnauthorized, "unauthorized"},
+		{"router client reads processors", http.MethodGet, "/volta-router/v1/processors", "router-key", http.StatusOK, ""},
+		{"router client cannot open circuits", http.MethodPost, "/volta-router/v1/circuits/RapidPay_BR/BR/open", "router-key", http.StatusForbidden, "forbidden"},
+		{"ingest writer cannot read processors", http.MethodGet, "/volta-router/v1/processors", "ingest-key", http.StatusForbidden, "forbidden"},
+		{"operator cannot load data", http.MethodPost, "/volta-router/v1/transactions/load", "operator-key", http.StatusForbidden, "forbidden"},
+		{"admin reads webhooks", http.MethodGet, "/volta-router/v1/webhooks", "admin-key", http.StatusOK, ""},
+		{"metrics need credentials", http.MethodGet, "/metrics", "", http.StatusUnauthorized, "unauthorized"},
+		{"router client cannot scrape metrics", http.MethodGet, "/metrics", "router-key", http.StatusForbidden, "forbidden"},
+		{"operator scrapes metrics", http.MethodGet, "/metrics", "operator-key", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+		if tt.apiKey != "" {
+			req.Header.Set("X-API-Key", tt.apiKey)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d (%s)", tt.name, tt.status, rec.Code, rec.Body.String())
+			continue
+		}
+		if tt.code == "" {
+			continue
+		}
+
+		var body models.ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		if body.Error != tt.code {
+			t.Errorf("%s: expected error code %s, got %s", tt.name, tt.code, body.Error)
+		}
+		if tt.status == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: expected a WWW-Authenticate header", tt.name)
+		}
+	}
+}
+
+func TestAuthBearerToken(t *testing.T) {
+	e := newAuthRouter(storage.NewInMemoryStore(), newTestAuthenticator(t), newDisabledLimiter())
+
+	valid, err := auth.SignJWT(jwtSecret, "volta", "dashboard", "", []string{auth.RoleOperator}, time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expired, _ := auth.SignJWT(jwtSecret, "volta", "dashboard", "", []string{auth.RoleOperator}, -time.Minute)
+	otherIssuer, _ := auth.SignJWT(jwtSecret, "someone-else", "dashboard", "", []string{auth.RoleOperator}, time.Minute)
+	wrongSecret, _ := auth.SignJWT([]byte("other-secret"), "volta", "dashboard", "", []string{auth.RoleOperator}, time.Minute)
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"valid token", valid, http.StatusOK},
+		{"expired token", expired, http.StatusUnauthorized},
+		{"other issuer", otherIssuer, http.StatusUnauthorized},
+		{"wrong secret", wrongSecret, http.StatusUnauthorized},
+		{"not a token", "abc", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/volta-router/v1/alerts", nil)
+		req.Header.Set("Authorization", "Bearer "+tt.token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d (%s)", tt.name, tt.status, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestAuthDisabled(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/volta-router/v1/webhooks", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200 with authentication disabled, got %d", rec.Code)
+	}
+}
+
+func TestNewAuthenticatorRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []config.APIKeyConfig
+	}{
+		{"missing key", []config.APIKeyConfig{{Client: "checkout", Roles: []string{auth.RoleRouterClient}}}},
+		{"no roles", []config.APIKeyConfig{{Client: "checkout", Key: "k"}}},
+		{"unknown role", []config.APIKeyConfig{{Client: "checkout", Key: "k", Roles: []string{"superuser"}}}},
+		{"duplicate key", []config.APIKeyConfig{
+			{Client: "checkout", Key: "k", Roles: []string{auth.RoleRouterClient}},
+			{Client: "ledger", Key: "k", Roles: []string{auth.RoleIngestWriter}},
+		}},
+	}
+
+	for _, tt := range tests {
+		if _, err := auth.NewAuthenticator(&config.SecurityConfig{AuthEnabled: true, APIKeys: tt.keys}); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestGRPCAuth(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	service := services.NewRoutingService(storage.NewInMemoryStore(), config.GetRoutingConfig())
+
+	client := serveTestRouter(t, service, grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.UnaryInterceptor(), grpcapi.UnaryAuthInterceptor(authenticator)),
+		grpc.ChainStreamInterceptor(grpcapi.StreamInterceptor(), grpcapi.StreamAuthInterceptor(authenticator)),
+	))
+
+	tests := []struct {
+		name   string
+		apiKey string
+		code   codes.Code
+	}{
+		{"missing key", "", codes.Unauthenticated},
+		{"unknown key", "wrong-key", codes.Unauthenticated},
+		{"wrong role", "ingest-key", codes.PermissionDenied},
+		{"router client", "router-key", codes.OK},
+	}
+
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.apiKey != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", tt.apiKey)
+		}
+
+		_, err := client.GetRoutingStats(ctx, &routerpb.GetRoutingStatsRequest{})
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%s: expected code %s, got %s (%v)", tt.name, tt.code, code, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+	"voltarides/smart-router/backtest"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/models"
+)
+
+// counterTotal sums every series of a counter in the metrics registry
+func counterTotal(t *testing.T, name string) float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	total := 0.0
+	for _, family := range families {
+		if family.GetName() == name {
+			for _, metric := range family.GetMetric() {
+				total += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return total
+}
+
+func TestBacktestRouterBeatsActualRouting(t *testing.T) {
+	start := time.Date(2024, 2, 26, 15, 0, 0, 0, time.UTC)
+
+	// Traffic alternates between a processor that always approves and one that always declines
+	transactions := make([]models.Transaction, 0, 120)
+	for i := 0; i < 60; i++ {
+		timestamp := start.Add(time.Duration(i) * 10 * time.Second)
+		transactions = append(transactions,
+			models.Transaction{ID: fmt.Sprintf("good_%d", i), Processor: "RapidPay_BR", Country: "BR", Currency: "BRL", Amount: 10, Status: "approved", Timestamp: timestamp},
+			models.Transaction{ID: fmt.Sprintf("bad_%d", i), Processor: "PayFlow_BR", Country: "BR", Currency: "BRL", Amount: 10, Status: "declined", Timestamp: timestamp},
+		)
+	}
+
+	report, err := backtest.Run(transactions, backtest.Options{WarmUp: time.Minute})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	results := make(map[string]backtest.StrategyResult)
+	for _, result := range report.Strategies {
+		results[result.Strategy] = result
+	}
+
+	actual := results[backtest.StrategyActual]
+	if actual.ApprovalRate != 50.0 {
+		t.Errorf("Expected actual approval rate 50.0, got %.2f", actual.ApprovalRate)
+	}
+
+	router := results[backtest.StrategyRouter]
+	if router.ApprovalRate != 100.0 {
+		t.Errorf("Expected smart router approval rate 100.0, got %.2f", router.ApprovalRate)
+	}
+	if router.Distribution["RapidPay_BR"] != router.Decisions {
+		t.Errorf("Expected every router decision to pick RapidPay_BR, got %v", router.Distribution)
+	}
+
+	// Decisions before the warm-up are not scored
+	if report.Scored != 108 {
+		t.Errorf("Expected 108 scored transactions after a 1 minute warm-up, got %d", report.Scored)
+	}
+}
+
+func TestBacktestNoTransactions(t *testing.T) {
+	if _, err := backtest.Run(nil, backtest.Options{}); err == nil {
+		t.Error("Expected error when replaying an empty dataset")
+	}
+}
+
+func TestBacktestDoesNotReportAsLiveTraffic(t *testing.T) {
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	start := time.Date(2024, 2, 26, 15, 0, 0, 0, time.UTC)
+	transactions := make([]models.Transaction, 0, 60)
+	for i := 0; i < 30; i++ {
+		timestamp := start.Add(time.Duration(i) * 10 * time.Second)
+		transactions = append(transactions,
+			models.Transaction{ID: fmt.Sprintf("good_%d", i), Processor: "RapidPay_BR", Country: "BR", Currency: "BRL", Amount: 10, Status: "approved", Timestamp: timestamp},
+			models.Transaction{ID: fmt.Sprintf("bad_%d", i), Processor: "PayFlow_BR", Country: "BR", Currency: "BRL", Amount: 10, Status: "declined", Timestamp: timestamp},
+		)
+	}
+
+	decisionsBefore := counterTotal(t, "volta_router_routing_decisions_total")
+	if _, err := backtest.Run(transactions, backtest.Options{WarmUp: time.Minute}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if decisions := counterTotal(t, "volta_router_routing_decisions_total") - decisionsBefore; decisions != 0 {
+		t.Errorf("Expected replayed decisions not to be counted, got %.0f", decisions)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("Expected the replay not to log through the default logger, got %s", logs.String())
+	}
+}
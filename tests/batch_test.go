@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestBatchRoutingResultsInOrder(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	reqs := []models.RoutingRequest{
+		{Amount: 100, Currency: "BRL", Country: "BR"},
+		{Amount: 100, Currency: "USD", Country: "US"},
+		{Amount: 250, Currency: "BRL", Country: "BR"},
+		{Amount: 100, Currency: "MXN", Country: "MX"},
+	}
+
+	responses, errs := service.SelectBestProcessorBatch(context.Background(), reqs, false, true)
+	if len(responses) != 4 || len(errs) != 4 {
+		t.Fatalf("Expected 4 results, got %d responses and %d errors", len(responses), len(errs))
+	}
+
+	for _, i := range []int{0, 2} {
+		if errs[i] != nil || responses[i] == nil || responses[i].Processor != "RapidPay_BR" {
+			t.Errorf("Expected item %d routed to RapidPay_BR, got %+v (%v)", i, responses[i], errs[i])
+		}
+	}
+	if responses[0].DecisionID == responses[2].DecisionID {
+		t.Error("Expected a separate decision per item")
+	}
+	if errs[1] == nil || errs[1].Error() != "country US not supported" {
+		t.Errorf("Expected unsupported country error for item 1, got %v", errs[1])
+	}
+	if errs[3] == nil || responses[3] != nil {
+		t.Errorf("Expected no data error for item 3, got %v", errs[3])
+	}
+
+	if count := store.CountRoutingDecisions(config.DefaultTenant); count != 2 {
+		t.Errorf("Expected 2 recorded decisions, got %d", count)
+	}
+
+	// PayFlow_BR's circuit is opened by the first item and stays open for the second
+	if events := store.GetCircuitEvents(config.DefaultTenant, "PayFlow_BR", "BR", time.Time{}, time.Time{}); len(events) != 1 {
+		t.Errorf("Expected 1 circuit transition across the batch, got %d", len(events))
+	}
+}
+
+func TestBatchRoutingSimulation(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	req := models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}
+	responses, errs := service.SelectBestProcessorBatch(context.Background(), []models.RoutingRequest{req, req}, true, false)
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("Expected no errors, got %v and %v", errs[0], errs[1])
+	}
+
+	if len(responses[0].SimulatedTransitions) != 1 || len(responses[1].SimulatedTransitions) != 0 {
+		t.Errorf("Expected the transition on the first item only, got %d and %d",
+			len(responses[0].SimulatedTransitions), len(responses[1].SimulatedTransitions))
+	}
+	if responses[0].DecisionID != "" || store.CountRoutingDecisions(config.DefaultTenant) != 0 {
+		t.Error("Expected simulated batch not to record decisions")
+	}
+	if store.GetCircuitOpenedAt(config.DefaultTenant, "PayFlow_BR", "BR") != nil {
+		t.Error("Expected simulated batch not to open circuits")
+	}
+}
+
+func TestBatchRoutingEndpoint(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	controller := controllers.NewRoutingController(service)
+
+	addProcessorTransactions(store, "RapidPay_CO", "CO", 9, 10, time.Now().Add(-5*time.Minute))
+
+	e := echo.New()
+	e.POST("/route/batch", controller.RouteBatch)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/route/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post(`[
+		{"amount": 100, "currency": "COP", "country": "CO"},
+		{"amount": 0, "currency": "COP", "country": "CO"},
+		{"amount": 100, "currency": "BRL", "country": "BR"}
+	]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var response models.BatchRoutingResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.Results) != 3 || response.Succeeded != 1 || response.Failed != 2 {
+		t.Fatalf("Expected 3 results with 1 success, got %d with %d", len(response.Results), response.Succeeded)
+	}
+	for i, result := range response.Results {
+		if result.Index != i {
+			t.Errorf("Expected result %d to have index %d, got %d", i, i, result.Index)
+		}
+	}
+	if response.Results[0].Decision == nil || response.Results[0].Decision.Processor != "RapidPay_CO" {
+		t.Errorf("Expected item 0 routed to RapidPay_CO, got %+v", response.Results[0])
+	}
+	if response.Results[1].Error == nil || response.Results[1].Error.Error != "validation_failed" {
+		t.Errorf("Expected validation_failed for item 1, got %+v", response.Results[1].Error)
+	}
+	if response.Results[2].Error == nil || response.Results[2].Error.Error != "no_processor_data" {
+		t.Errorf("Expected no_processor_data for item 2, got %+v", response.Results[2].Error)
+	}
+
+	if rec := post(`[]`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an empty batch, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+// addProcessorTransactions adds total transactions for a processor, the first approved of which are approved
+func addProcessorTransactions(store *storage.InMemoryStore, processor, country string, approved, total int, timestamp time.Time) {
+	transactions := make([]models.Transaction, 0, total)
+	for i := 0; i < total; i++ {
+		status := "declined"
+		if i < approved {
+			status = "approved"
+		}
+		transactions = append(transactions, models.Transaction{
+			ID:        processor + "_tx",
+			Processor: processor,
+			Country:   country,
+			Status:    status,
+			Timestamp: timestamp,
+		})
+	}
+	store.AddTransactions(transactions)
+}
+
+func TestForceOpenCircuitExcludesProcessor(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+
+	_, err := service.OverrideCircuit("RapidPay_BR", "BR", models.CircuitOpen, "acquirer maintenance", "ops@volta", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	response, err := service.SelectBestProcessor(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Processor != "TurboAcquire_BR" {
+		t.Errorf("Expected processor TurboAcquire_BR while RapidPay_BR is forced open, got %s", response.Processor)
+	}
+
+	stat, err := service.GetProcessorStats("RapidPay_BR")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stat.CircuitState != models.CircuitOpen {
+		t.Errorf("Expected circuit state open, got %s", stat.CircuitState)
+	}
+	if stat.CircuitOverride == nil || stat.CircuitOverride.Operator != "ops@volta" {
+		t.Errorf("Expected override by ops@volta in processor stats, got %+v", stat.CircuitOverride)
+	}
+}
+
+func TestForceCloseCircuitKeepsProcessorRoutable(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	// Below threshold without an override, the circuit opens automatically
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+
+	_, err := service.OverrideCircuit("PayFlow_BR", "BR", models.CircuitClosed, "false positive", "ops@volta", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	response, err := service.SelectBestProcessor(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Processor != "PayFlow_BR" {
+		t.Errorf("Expected processor PayFlow_BR while forced closed, got %s", response.Processor)
+	}
+
+	if store.GetCircuitOpenedAt(config.DefaultTenant, "PayFlow_BR", "BR") != nil {
+		t.Error("Expected automatic circuit state to be cleared by a forced close")
+	}
+}
+
+func TestCircuitOverrideExpires(t *testing.T) {
+	store := storage.NewInMemoryStore()
+
+	store.SetCircuitOverride(config.DefaultTenant, "RapidPay_BR", "BR", storage.CircuitOverrideInfo{
+		State:     models.CircuitOpen,
+		Reason:    "maintenance",
+		Operator:  "ops@volta",
+		CreatedAt: time.Now().Add(-time.Hour),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+
+	if store.GetCircuitOverride(config.DefaultTenant, "RapidPay_BR", "BR") != nil {
+		t.Error("Expected expired override to be ignored")
+	}
+
+	if state := store.GetCircuitState(config.DefaultTenant, "RapidPay_BR", "BR", 5*time.Minute); state != models.CircuitClosed {
+		t.Errorf("Expected circuit state closed after override expiry, got %s", state)
+	}
+}
+
+func TestCircuitOverrideValidation(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	if _, err := service.OverrideCircuit("RapidPay_BR", "MX", models.CircuitOpen, "r", "o", time.Now().Add(time.Hour)); err == nil {
+		t.Error("Expected error for processor outside its country")
+	}
+
+	if _, err := service.OverrideCircuit("RapidPay_BR", "BR", "broken", "r", "o", time.Now().Add(time.Hour)); err == nil {
+		t.Error("Expected error for invalid circuit state")
+	}
+
+	if _, err := service.OverrideCircuit("RapidPay_BR", "BR", models.CircuitOpen, "r", "o", time.Now().Add(-time.Hour)); err == nil {
+		t.Error("Expected error for expiry in the past")
+	}
+
+	if _, err := service.ReleaseCircuitOverride("RapidPay_BR", "BR"); err == nil {
+		t.Error("Expected error when releasing a circuit without an override")
+	}
+}
+
+func TestCircuitHistoryRecordsTransitions(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	// Routing opens the PayFlow_BR circuit (50% < 60%)
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Operator releases it after investigation
+	if _, err := service.OverrideCircuit("PayFlow_BR", "BR", models.CircuitClosed, "false positive", "ops@volta", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events, err := service.GetCircuitHistory("PayFlow_BR", "BR", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 circuit events, got %d", len(events))
+	}
+
+	if events[0].FromState != models.CircuitClosed || events[0].ToState != models.CircuitOpen {
+		t.Errorf("Expected closed -> open transition, got %s -> %s", events[0].FromState, events[0].ToState)
+	}
+	if events[0].Trigger != models.CircuitTriggerLowApprovalRate || events[0].ApprovalRate != 50.0 {
+		t.Errorf("Expected low approval rate trigger at 50%%, got %s at %.2f", events[0].Trigger, events[0].ApprovalRate)
+	}
+
+	if events[1].ToState != models.CircuitClosed || events[1].Trigger != models.CircuitTriggerManualOverride {
+		t.Errorf("Expected manual close transition, got %s via %s", events[1].ToState, events[1].Trigger)
+	}
+	if events[1].Operator != "ops@volta" {
+		t.Errorf("Expected operator ops@volta, got %s", events[1].Operator)
+	}
+
+	// Events outlive the circuit record itself
+	if store.GetCircuitOpenedAt(config.DefaultTenant, "PayFlow_BR", "BR") != nil {
+		t.Error("Expected circuit record to be removed after close")
+	}
+}
+
+func TestCircuitHistoryFilters(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	now := time.Now()
+
+	store.RecordCircuitEvent(models.CircuitEvent{Processor: "PayFlow_BR", Country: "BR", ToState: models.CircuitOpen, Timestamp: now.Add(-2 * time.Hour)})
+	store.RecordCircuitEvent(models.CircuitEvent{Processor: "PayFlow_BR", Country: "BR", ToState: models.CircuitClosed, Timestamp: now.Add(-time.Hour)})
+	store.RecordCircuitEvent(models.CircuitEvent{Processor: "PayFlow_MX", Country: "MX", ToState: models.CircuitOpen, Timestamp: now.Add(-30 * time.Minute)})
+
+	if events := store.GetCircuitEvents(config.DefaultTenant, "", "BR", time.Time{}, time.Time{}); len(events) != 2 {
+		t.Errorf("Expected 2 events for BR, got %d", len(events))
+	}
+
+	if events := store.GetCircuitEvents(config.DefaultTenant, "", "", now.Add(-90*time.Minute), time.Time{}); len(events) != 2 {
+		t.Errorf("Expected 2 events in the last 90 minutes, got %d", len(events))
+	}
+
+	if events := store.GetCircuitEvents(config.DefaultTenant, "PayFlow_BR", "", time.Time{}, now.Add(-90*time.Minute)); len(events) != 1 {
+		t.Errorf("Expected 1 PayFlow_BR event older than 90 minutes, got %d", len(events))
+	}
+}
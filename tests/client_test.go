@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"voltarides/smart-router/client"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/storage"
+)
+
+func TestClientRouteAndIngest(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+
+	server := httptest.NewServer(newRouter(store))
+	defer server.Close()
+
+	router := client.New(server.URL)
+	ctx := context.Background()
+	req := models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}
+
+	simulated, err := router.Simulate(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if simulated.DecisionID != "" {
+		t.Errorf("Expected no decision ID in simulation mode, got %s", simulated.DecisionID)
+	}
+
+	decision, err := router.RouteWithFailover(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decision.Processor != "RapidPay_BR" || decision.Fallback == nil || decision.Fallback.Processor != "TurboAcquire_BR" {
+		t.Errorf("Expected RapidPay_BR with TurboAcquire_BR as fallback, got %+v", decision)
+	}
+
+	tx, err := router.RecordTransaction(ctx, models.TransactionOutcomeRequest{
+		DecisionID: decision.DecisionID,
+		Country:    "BR",
+		Currency:   "BRL",
+		Amount:     100,
+		Status:     "approved",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if tx.Processor != "RapidPay_BR" {
+		t.Errorf("Expected outcome on the decision's processor RapidPay_BR, got %s", tx.Processor)
+	}
+
+	stats, err := router.RoutingStats(ctx, models.RoutingStatsQuery{Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Decisions != 1 || stats.Distribution["RapidPay_BR"] != 1 {
+		t.Errorf("Expected 1 recorded decision for RapidPay_BR, got %d (%v)", stats.Decisions, stats.Distribution)
+	}
+
+	processor, err := router.Processor(ctx, "TurboAcquire_BR")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if processor.ApprovalRate != 80.0 {
+		t.Errorf("Expected approval rate 80, got %.2f", processor.ApprovalRate)
+	}
+}
+
+func TestClientReturnsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(newRouter(storage.NewInMemoryStore()))
+	defer server.Close()
+
+	router := client.New(server.URL, client.WithFallbackProcessors(map[string]string{"US": "RapidPay_US"}))
+
+	_, err := router.Route(context.Background(), models.RoutingRequest{Amount: 100, Currency: "USD", Country: "US"})
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != "unsupported_country" {
+		t.Errorf("Expected 400 unsupported_country without fallback, got %d %s", apiErr.StatusCode, apiErr.Code)
+	}
+
+	if _, err := router.Processor(context.Background(), "Unknown"); !errors.As(err, &apiErr) || apiErr.Code != "processor_not_found" {
+		t.Errorf("Expected processor_not_found, got %v", err)
+	}
+}
+
+func TestClientRetriesGatewayErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"processor": "RapidPay_BR", "approval_rate": 90, "risk_level": "low"}`))
+	}))
+	defer server.Close()
+
+	router := client.New(server.URL, client.WithRetryPolicy(3, time.Millisecond, 10*time.Millisecond))
+
+	decision, err := router.Route(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected success on the third attempt, got %v", err)
+	}
+	if decision.Processor != "RapidPay_BR" {
+		t.Errorf("Expected processor RapidPay_BR, got %s", decision.Processor)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestClientDoesNotReplayIngest(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The outcome is ingested, but the response arrives after the client gave up
+		atomic.AddInt32(&attempts, 1)
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	router := client.New(server.URL,
+		client.WithTimeout(20*time.Millisecond),
+		client.WithRetryPolicy(3, time.Millisecond, 10*time.Millisecond),
+	)
+
+	_, err := router.RecordTransaction(context.Background(), models.TransactionOutcomeRequest{Processor: "RapidPay_BR", Country: "BR", Currency: "BRL", Amount: 100, Status: "approved"})
+	if err == nil {
+		t.Fatal("Expected a timeout error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("Expected the outcome to be sent once, got %d attempts", got)
+	}
+}
+
+func TestClientRetriesRateLimitedIngest(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": "rate_limited", "message": "request quota exceeded"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "tx1"}`))
+	}))
+	defer server.Close()
+
+	router := client.New(server.URL, client.WithRetryPolicy(3, time.Millisecond, 10*time.Millisecond))
+
+	tx, err := router.RecordTransaction(context.Background(), models.TransactionOutcomeRequest{Processor: "RapidPay_BR", Country: "BR", Currency: "BRL", Amount: 100, Status: "approved"})
+	if err != nil || tx.ID != "tx1" {
+		t.Fatalf("Expected tx1 on the second attempt, got %+v (%v)", tx, err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClientDoesNotRetryRoutingErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "all_circuits_open", "message": "all processor circuits are open for country BR"}`))
+	}))
+	defer server.Close()
+
+	router := client.New(server.URL,
+		client.WithRetryPolicy(3, time.Millisecond, 10*time.Millisecond),
+		client.WithFallbackProcessors(map[string]string{"BR": "PayFlow_BR"}),
+	)
+
+	_, err := router.Route(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "all_circuits_open" {
+		t.Errorf("Expected all_circuits_open from the router rather than the fallback, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestClientFallbackWhenUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	router := client.New(url,
+		client.WithTimeout(100*time.Millisecond),
+		client.WithRetryPolicy(2, time.Millisecond, time.Millisecond),
+		client.WithFallbackProcessors(map[string]string{"BR": "PayFlow_BR"}),
+	)
+
+	decision, err := router.Route(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected fallback decision, got %v", err)
+	}
+	if decision.Processor != "PayFlow_BR" || !client.IsFallback(decision) {
+		t.Errorf("Expected fallback processor PayFlow_BR, got %+v", decision)
+	}
+
+	// No fallback configured for the country
+	if _, err := router.Route(context.Background(), models.RoutingRequest{Amount: 100, Currency: "MXN", Country: "MX"}); err == nil {
+		t.Error("Expected network error for a country without a fallback processor")
+	}
+
+	// Simulation never falls back
+	if _, err := router.Simulate(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}); err == nil {
+		t.Error("Expected network error in simulation mode")
+	}
+}
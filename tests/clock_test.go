@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/data/generator"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+// newFakeClockService creates a store and routing service driven by a fake clock
+func newFakeClockService() (*clock.Simulated, *storage.InMemoryStore, *services.RoutingService) {
+	fakeClock := clock.NewSimulated(time.Date(2024, 2, 26, 15, 0, 0, 0, time.UTC))
+	store := storage.NewInMemoryStore(storage.WithClock(fakeClock))
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	return fakeClock, store, service
+}
+
+func TestCircuitTransitionsToHalfOpenAfterTimeout(t *testing.T) {
+	fakeClock, store, _ := newFakeClockService()
+	timeout := 5 * time.Minute
+
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+
+	fakeClock.Advance(timeout)
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", timeout); state != models.CircuitOpen {
+		t.Errorf("Expected circuit to stay open exactly at the timeout, got %s", state)
+	}
+
+	fakeClock.Advance(time.Second)
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", timeout); state != models.CircuitHalfOpen {
+		t.Errorf("Expected circuit to be half-open after the timeout, got %s", state)
+	}
+}
+
+func TestHalfOpenCircuitClosesOnRecovery(t *testing.T) {
+	fakeClock, store, service := newFakeClockService()
+
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+	fakeClock.Advance(6 * time.Minute)
+
+	// The processor has recovered to 80% within the window
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 8, 10, fakeClock.Now().Add(-time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	response, err := service.SelectBestProcessor(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Processor != "PayFlow_BR" {
+		t.Errorf("Expected recovered PayFlow_BR to be routable, got %s", response.Processor)
+	}
+
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", 5*time.Minute); state != models.CircuitClosed {
+		t.Errorf("Expected circuit to close after recovery, got %s", state)
+	}
+
+	// The timeout transition is stamped when it took effect, the recovery when it was observed
+	events := store.GetCircuitEvents(config.DefaultTenant, "PayFlow_BR", "BR", time.Time{}, time.Time{})
+	if len(events) != 2 || events[0].ToState != models.CircuitHalfOpen || events[1].FromState != models.CircuitHalfOpen || events[1].ToState != models.CircuitClosed {
+		t.Fatalf("Expected open -> half_open and half_open -> closed events, got %+v", events)
+	}
+	if events[0].Trigger != models.CircuitTriggerTimeout || !events[0].Timestamp.Equal(fakeClock.Now().Add(-time.Minute)) {
+		t.Errorf("Expected a breaker_timeout event at %v, got %s at %v", fakeClock.Now().Add(-time.Minute), events[0].Trigger, events[0].Timestamp)
+	}
+	if !events[1].Timestamp.Equal(fakeClock.Now()) {
+		t.Errorf("Expected event timestamp %v, got %v", fakeClock.Now(), events[1].Timestamp)
+	}
+}
+
+func TestHalfOpenCircuitReopensWhileFailing(t *testing.T) {
+	fakeClock, store, service := newFakeClockService()
+
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, fakeClock.Now())
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+	fakeClock.Advance(6 * time.Minute)
+
+	// Still failing at 40%
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 4, 10, fakeClock.Now().Add(-time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", 5*time.Minute); state != models.CircuitOpen {
+		t.Errorf("Expected circuit to reopen while failing, got %s", state)
+	}
+
+	openedAt := store.GetCircuitOpenedAt(config.DefaultTenant, "PayFlow_BR", "BR")
+	if openedAt == nil || !openedAt.Equal(fakeClock.Now()) {
+		t.Errorf("Expected reopened circuit timeout to restart at %v, got %v", fakeClock.Now(), openedAt)
+	}
+}
+
+func TestTimedCircuitTransitionsAreRecorded(t *testing.T) {
+	fakeClock, store, service := newFakeClockService()
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, fakeClock.Now())
+
+	// A breaker opened by routing, then pinned open for two minutes
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+	if _, err := service.OverrideCircuit("PayFlow_BR", "BR", models.CircuitOpen, "maintenance", "ops@volta", fakeClock.Now().Add(2*time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Simulations read the breaker without recording what they see
+	fakeClock.Advance(10 * time.Minute)
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, fakeClock.Now())
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	if _, err := service.SelectBestProcessor(context.Background(), req, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if events := store.GetCircuitEvents(config.DefaultTenant, "PayFlow_BR", "BR", time.Time{}, time.Time{}); len(events) != 1 {
+		t.Fatalf("Expected only the override event after a simulation, got %+v", events)
+	}
+
+	// The first live read records the override expiry, then the timeout, each stamped when it took effect
+	stat, _ := service.GetProcessorStats("PayFlow_BR")
+	if stat.CircuitState != models.CircuitHalfOpen || stat.CircuitOverride != nil {
+		t.Errorf("Expected a half-open circuit without override, got %s with %+v", stat.CircuitState, stat.CircuitOverride)
+	}
+	service.GetProcessorStats("PayFlow_BR")
+
+	start := time.Date(2024, 2, 26, 15, 0, 0, 0, time.UTC)
+	events := store.GetCircuitEvents(config.DefaultTenant, "PayFlow_BR", "BR", time.Time{}, time.Time{})
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 circuit events, got %+v", events)
+	}
+	if events[1].Trigger != models.CircuitTriggerOverrideExpired || events[1].ToState != models.CircuitOpen || !events[1].Timestamp.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("Expected the override to expire into the open breaker at %v, got %+v", start.Add(2*time.Minute), events[1])
+	}
+	if events[2].Trigger != models.CircuitTriggerTimeout || events[2].ToState != models.CircuitHalfOpen || !events[2].Timestamp.Equal(start.Add(5*time.Minute)) {
+		t.Errorf("Expected the breaker to turn half-open at %v, got %+v", start.Add(5*time.Minute), events[2])
+	}
+}
+
+func TestTransactionWindowBoundaries(t *testing.T) {
+	fakeClock, store, _ := newFakeClockService()
+	window := 15 * time.Minute
+	now := fakeClock.Now()
+
+	store.AddTransactions([]models.Transaction{
+		{ID: "edge", Processor: "RapidPay_BR", Country: "BR", Status: "approved", Timestamp: now.Add(-window)},
+		{ID: "inside", Processor: "RapidPay_BR", Country: "BR", Status: "approved", Timestamp: now.Add(-window + time.Nanosecond)},
+		{ID: "now", Processor: "RapidPay_BR", Country: "BR", Status: "approved", Timestamp: now},
+		{ID: "future", Processor: "RapidPay_BR", Country: "BR", Status: "approved", Timestamp: now.Add(time.Second)},
+	})
+
+	// Transactions stamped ahead of the clock (e.g. by a client with clock skew) still count
+	results := store.GetTransactionsByWindow(config.DefaultTenant, "RapidPay_BR", "BR", window)
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 transactions after now-window, got %d", len(results))
+	}
+	for _, tx := range results {
+		if tx.ID == "edge" {
+			t.Errorf("Unexpected transaction in window: %s", tx.ID)
+		}
+	}
+
+	// Once the clock moves, the oldest leaves
+	fakeClock.Advance(time.Second)
+	results = store.GetTransactionsByWindow(config.DefaultTenant, "RapidPay_BR", "BR", window)
+	if len(results) != 2 {
+		t.Errorf("Expected 2 transactions after advancing one second, got %d", len(results))
+	}
+}
+
+func TestGeneratorUsesClock(t *testing.T) {
+	fakeClock := clock.NewSimulated(time.Date(2024, 2, 26, 15, 0, 0, 0, time.UTC))
+
+	transactions := generator.GenerateTestTransactions(90, fakeClock)
+	if len(transactions) != 90 {
+		t.Fatalf("Expected 90 transactions, got %d", len(transactions))
+	}
+
+	for _, tx := range transactions {
+		if tx.Timestamp.After(fakeClock.Now()) || tx.Timestamp.Before(fakeClock.Now().Add(-10*time.Minute)) {
+			t.Fatalf("Expected timestamps within the last 10 minutes of the fake clock, got %v", tx.Timestamp)
+		}
+	}
+
+	// Adjusting moves the latest transaction to the clock's current time
+	fakeClock.Advance(time.Hour)
+	adjusted := generator.AdjustTransactionTimestamps(transactions, fakeClock)
+	latest := adjusted[0].Timestamp
+	for _, tx := range adjusted {
+		if tx.Timestamp.After(latest) {
+			latest = tx.Timestamp
+		}
+	}
+	if !latest.Equal(fakeClock.Now()) {
+		t.Errorf("Expected latest adjusted timestamp %v, got %v", fakeClock.Now(), latest)
+	}
+}
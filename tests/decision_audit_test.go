@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+func TestRoutingDecisionRecordsExplanation(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := store.Clock().Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	if _, err := service.OverrideCircuit("RapidPay_BR", "BR", models.CircuitOpen, "maintenance", "ops@volta", now.Add(time.Hour)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	req := models.RoutingRequest{Amount: 250.0, Currency: "BRL", Country: "BR"}
+	if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	page, err := service.GetRoutingDecisions("", "", time.Time{}, time.Time{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page.Decisions) != 1 {
+		t.Fatalf("Expected 1 decision, got %d", len(page.Decisions))
+	}
+
+	decision := page.Decisions[0]
+	if decision.ID == "" {
+		t.Error("Expected decision ID to be set")
+	}
+	if decision.Processor != "TurboAcquire_BR" || decision.Amount != 250.0 || decision.Currency != "BRL" {
+		t.Errorf("Expected TurboAcquire_BR for 250 BRL, got %s for %.2f %s", decision.Processor, decision.Amount, decision.Currency)
+	}
+	if decision.Strategy != models.StrategyHighestApprovalRate {
+		t.Errorf("Expected strategy %s, got %s", models.StrategyHighestApprovalRate, decision.Strategy)
+	}
+	if decision.ConfigVersion != cfg.Version() {
+		t.Errorf("Expected config version %s, got %s", cfg.Version(), decision.ConfigVersion)
+	}
+
+	if len(decision.Candidates) != 1 || decision.Candidates[0].Processor != "TurboAcquire_BR" {
+		t.Errorf("Expected TurboAcquire_BR as the only candidate, got %+v", decision.Candidates)
+	}
+
+	reasons := make(map[string]string)
+	for _, exclusion := range decision.Excluded {
+		reasons[exclusion.Processor] = exclusion.Reason
+	}
+	if reasons["RapidPay_BR"] != models.ExclusionCircuitForcedOpen {
+		t.Errorf("Expected RapidPay_BR excluded as %s, got %s", models.ExclusionCircuitForcedOpen, reasons["RapidPay_BR"])
+	}
+	if reasons["PayFlow_BR"] != models.ExclusionLowApprovalRate {
+		t.Errorf("Expected PayFlow_BR excluded as %s, got %s", models.ExclusionLowApprovalRate, reasons["PayFlow_BR"])
+	}
+
+	found, err := service.GetRoutingDecision(decision.ID)
+	if err != nil || found.Processor != decision.Processor {
+		t.Errorf("Expected decision %s to be found by ID, got %v", decision.ID, err)
+	}
+	if _, err := service.GetRoutingDecision("missing"); err == nil {
+		t.Error("Expected error for unknown decision ID")
+	}
+}
+
+func TestRoutingDecisionsPagination(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		store.RecordRoutingDecision(models.RoutingDecision{
+			ID:        string(rune('a' + i)),
+			Processor: "RapidPay_BR",
+			Country:   "BR",
+			Timestamp: now.Add(time.Duration(i) * time.Minute).Format(time.RFC3339),
+		})
+	}
+	store.RecordRoutingDecision(models.RoutingDecision{ID: "mx", Processor: "PayFlow_MX", Country: "MX", Timestamp: now.Format(time.RFC3339)})
+
+	page, err := service.GetRoutingDecisions("", "BR", time.Time{}, time.Time{}, 0, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if page.Total != 5 || len(page.Decisions) != 2 {
+		t.Fatalf("Expected 2 of 5 BR decisions, got %d of %d", len(page.Decisions), page.Total)
+	}
+	if page.Decisions[0].ID != "e" {
+		t.Errorf("Expected newest decision first, got %s", page.Decisions[0].ID)
+	}
+	if page.NextOffset == nil || *page.NextOffset != 2 {
+		t.Errorf("Expected next offset 2, got %v", page.NextOffset)
+	}
+
+	last, err := service.GetRoutingDecisions("", "BR", time.Time{}, time.Time{}, 4, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(last.Decisions) != 1 || last.NextOffset != nil {
+		t.Errorf("Expected a final page with 1 decision, got %d (next %v)", len(last.Decisions), last.NextOffset)
+	}
+
+	recent, err := service.GetRoutingDecisions("RapidPay_BR", "", now.Add(150*time.Second), time.Time{}, 0, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if recent.Total != 2 {
+		t.Errorf("Expected 2 decisions after the from filter, got %d", recent.Total)
+	}
+
+	if _, err := service.GetRoutingDecisions("", "", time.Time{}, time.Time{}, 0, 0); err == nil {
+		t.Error("Expected error for zero limit")
+	}
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+func TestDecisionOutcomeFeedback(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	now := store.Clock().Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	statuses := []string{"approved", "declined", "approved", "approved"}
+	for i, status := range statuses {
+		response, err := service.SelectBestProcessor(context.Background(), req, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if response.DecisionID == "" {
+			t.Fatal("Expected decision_id in routing response")
+		}
+
+		outcome := models.TransactionOutcomeRequest{
+			DecisionID: response.DecisionID,
+			Country:    "BR",
+			Currency:   "BRL",
+			Amount:     100.0,
+			Status:     status,
+		}
+		// The last payment was retried on the fallback processor
+		if i == len(statuses)-1 {
+			outcome.Processor = "TurboAcquire_BR"
+		}
+
+		tx, err := service.RecordOutcome(outcome)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if i < len(statuses)-1 && tx.Processor != response.Processor {
+			t.Errorf("Expected outcome processor to default to %s, got %s", response.Processor, tx.Processor)
+		}
+	}
+
+	stats, err := service.GetRoutingEffectiveness("", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	overall := stats.Overall
+	if overall.Decisions != 4 || overall.Outcomes != 3 || overall.Approved != 2 || overall.FallbackUsed != 1 {
+		t.Fatalf("Expected 4 decisions, 3 outcomes, 2 approved and 1 fallback, got %+v", overall)
+	}
+
+	expectedRate := 2.0 / 3.0 * 100.0
+	if math.Abs(overall.ApprovalRate-expectedRate) > 0.01 {
+		t.Errorf("Expected approval rate %.2f, got %.2f", expectedRate, overall.ApprovalRate)
+	}
+
+	// Outcomes feed back into the window, so the predicted and runner-up rates move between decisions
+	if overall.BestAlternativeRate <= 0 || math.Abs(overall.Regret-(overall.BestAlternativeRate-expectedRate)) > 0.01 {
+		t.Errorf("Expected regret to be best alternative rate minus realised rate, got %+v", overall)
+	}
+
+	if len(stats.Processors) != 1 || stats.Processors[0].Processor != "RapidPay_BR" {
+		t.Errorf("Expected effectiveness for RapidPay_BR only, got %+v", stats.Processors)
+	}
+}
+
+func TestRecordOutcomeValidation(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	store.RecordRoutingDecision(models.RoutingDecision{ID: "d1", Processor: "RapidPay_BR", Country: "BR", Timestamp: time.Now().Format(time.RFC3339)})
+
+	if _, err := service.RecordOutcome(models.TransactionOutcomeRequest{DecisionID: "missing", Country: "BR", Currency: "BRL", Amount: 1, Status: "approved"}); err == nil {
+		t.Error("Expected error for unknown decision")
+	}
+
+	if _, err := service.RecordOutcome(models.TransactionOutcomeRequest{DecisionID: "d1", Country: "MX", Currency: "MXN", Amount: 1, Status: "approved"}); err == nil {
+		t.Error("Expected error for outcome in a different country than its decision")
+	}
+
+	if _, err := service.RecordOutcome(models.TransactionOutcomeRequest{Country: "BR", Currency: "BRL", Amount: 1, Status: "approved"}); err == nil {
+		t.Error("Expected error for unlinked outcome without a processor")
+	}
+}
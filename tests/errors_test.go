@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/common/apierror"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRoutingSentinelErrors(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	// Every MX processor below the breaker threshold
+	now := time.Now()
+	for _, processor := range config.ProcessorsByCountry["MX"] {
+		addProcessorTransactions(store, processor, "MX", 4, 10, now.Add(-5*time.Minute))
+	}
+
+	tests := []struct {
+		name    string
+		request models.RoutingRequest
+		target  error
+		message string
+	}{
+		{"unsupported country", models.RoutingRequest{Amount: 100, Currency: "USD", Country: "US"}, services.ErrUnsupportedCountry, "country US not supported"},
+		{"invalid currency", models.RoutingRequest{Amount: 100, Currency: "USD", Country: "BR"}, services.ErrInvalidCurrency, "currency USD not accepted for country BR (expected BRL)"},
+		{"no data", models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}, services.ErrNoData, "no processor data available for country BR"},
+		{"all circuits open", models.RoutingRequest{Amount: 100, Currency: "MXN", Country: "MX"}, services.ErrAllCircuitsOpen, "all processor circuits are open for country MX"},
+	}
+
+	for _, tt := range tests {
+		_, err := service.SelectBestProcessor(context.Background(), tt.request, false)
+		if !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected error matching %v, got %v", tt.name, tt.target, err)
+			continue
+		}
+		if err.Error() != tt.message {
+			t.Errorf("%s: expected message %q, got %q", tt.name, tt.message, err.Error())
+		}
+	}
+}
+
+func TestRouteErrorStatuses(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	controller := controllers.NewRoutingController(service)
+
+	store.OpenCircuit(config.DefaultTenant, "RapidPay_CO", "CO")
+	store.OpenCircuit(config.DefaultTenant, "TurboAcquire_CO", "CO")
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_CO", "CO")
+
+	e := echo.New()
+	e.POST("/route", controller.RouteTransaction)
+
+	tests := []struct {
+		body   string
+		status int
+		code   string
+	}{
+		{`{"amount": 100, "currency": "USD", "country": "US"}`, http.StatusBadRequest, "unsupported_country"},
+		{`{"amount": 100, "currency": "MXN", "country": "BR"}`, http.StatusUnprocessableEntity, "invalid_currency"},
+		{`{"amount": 100, "currency": "BRL", "country": "BR"}`, http.StatusServiceUnavailable, "no_processor_data"},
+		{`{"amount": 100, "currency": "COP", "country": "CO"}`, http.StatusServiceUnavailable, "all_circuits_open"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/route", strings.NewReader(tt.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var response models.ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if rec.Code != tt.status || response.Error != tt.code {
+			t.Errorf("Expected %d %s for %s, got %d %s", tt.status, tt.code, tt.body, rec.Code, response.Error)
+		}
+	}
+}
+
+func TestErrorCatalogCoversRoutingErrors(t *testing.T) {
+	catalog := make(map[string]models.ErrorCatalogEntry)
+	for _, entry := range apierror.Catalog() {
+		catalog[entry.Code] = entry
+	}
+
+	for _, err := range []error{services.ErrUnsupportedCountry, services.ErrInvalidCurrency, services.ErrNoData, services.ErrAllCircuitsOpen, errors.New("unexpected")} {
+		apiErr := apierror.Routing(err)
+		entry, listed := catalog[apiErr.Code]
+		if !listed {
+			t.Errorf("Expected %s in the error catalog", apiErr.Code)
+			continue
+		}
+		if entry.HTTPStatus != apiErr.Status {
+			t.Errorf("Expected catalog status %d for %s, got %d", apiErr.Status, apiErr.Code, entry.HTTPStatus)
+		}
+	}
+
+	if entry := catalog["invalid_currency"]; entry.GRPCCode != "InvalidArgument" {
+		t.Errorf("Expected gRPC code InvalidArgument for invalid_currency, got %s", entry.GRPCCode)
+	}
+}
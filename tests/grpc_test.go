@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/grpcapi"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestRouterClient serves the gRPC router over an in-memory listener and returns a connected client
+func newTestRouterClient(t *testing.T, service *services.RoutingService) routerpb.RouterServiceClient {
+	t.Helper()
+
+	return serveTestRouter(t, service, grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(grpcapi.StreamInterceptor()),
+	))
+}
+
+// serveTestRouter registers the router on server, serves it over an in-memory listener and returns a connected client
+func serveTestRouter(t *testing.T, service *services.RoutingService, server *grpc.Server) routerpb.RouterServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	routerpb.RegisterRouterServiceServer(server, grpcapi.NewRouterServer(service))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return routerpb.NewRouterServiceClient(conn)
+}
+
+func TestGRPCRoute(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	client := newTestRouterClient(t, service)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 8, 10, now.Add(-5*time.Minute))
+
+	response, err := client.Route(context.Background(), &routerpb.RouteRequest{Amount: 100, Currency: "BRL", Country: "BR", Failover: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Processor != "RapidPay_BR" || response.ApprovalRate != 90.0 || response.DecisionId == "" {
+		t.Errorf("Expected RapidPay_BR at 90%% with a decision ID, got %s at %.1f%% (%q)", response.Processor, response.ApprovalRate, response.DecisionId)
+	}
+	if response.Fallback == nil || response.Fallback.Processor != "TurboAcquire_BR" {
+		t.Errorf("Expected fallback TurboAcquire_BR, got %+v", response.Fallback)
+	}
+
+	// Decisions made over gRPC show up in the shared routing stats
+	stats, err := client.GetRoutingStats(context.Background(), &routerpb.GetRoutingStatsRequest{Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Decisions != 1 || stats.Distribution["RapidPay_BR"] != 1 {
+		t.Errorf("Expected 1 decision for RapidPay_BR, got %d (%v)", stats.Decisions, stats.Distribution)
+	}
+}
+
+func TestGRPCRouteErrors(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	client := newTestRouterClient(t, service)
+
+	tests := []struct {
+		name    string
+		request *routerpb.RouteRequest
+		code    codes.Code
+		prefix  string
+	}{
+		{"validation", &routerpb.RouteRequest{Amount: -1, Currency: "BRL", Country: "BR"}, codes.InvalidArgument, "validation_failed"},
+		{"unsupported country", &routerpb.RouteRequest{Amount: 100, Currency: "USD", Country: "US"}, codes.InvalidArgument, "unsupported_country"},
+		{"no data", &routerpb.RouteRequest{Amount: 100, Currency: "BRL", Country: "BR"}, codes.Unavailable, "no_processor_data"},
+	}
+
+	for _, tt := range tests {
+		_, err := client.Route(context.Background(), tt.request)
+		st, _ := status.FromError(err)
+		if st.Code() != tt.code || !strings.HasPrefix(st.Message(), tt.prefix+": ") {
+			t.Errorf("%s: expected %s with %s prefix, got %s: %s", tt.name, tt.code, tt.prefix, st.Code(), st.Message())
+		}
+	}
+}
+
+func TestGRPCProcessorStats(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	client := newTestRouterClient(t, service)
+
+	addProcessorTransactions(store, "RapidPay_MX", "MX", 7, 10, time.Now().Add(-5*time.Minute))
+
+	response, err := client.GetProcessorStats(context.Background(), &routerpb.GetProcessorStatsRequest{Country: "MX"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Processors) != 3 {
+		t.Errorf("Expected 3 MX processors, got %d", len(response.Processors))
+	}
+
+	response, err = client.GetProcessorStats(context.Background(), &routerpb.GetProcessorStatsRequest{Name: "RapidPay_MX"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Processors) != 1 || response.Processors[0].ApprovalRate != 70.0 || response.Processors[0].TransactionCount != 10 {
+		t.Errorf("Expected RapidPay_MX at 70%% over 10 transactions, got %+v", response.Processors)
+	}
+
+	_, err = client.GetProcessorStats(context.Background(), &routerpb.GetProcessorStatsRequest{Name: "Unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown processor, got %s", status.Code(err))
+	}
+}
+
+func TestGRPCIngestTransactions(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	client := newTestRouterClient(t, service)
+
+	stream, err := client.IngestTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	outcomes := []*routerpb.TransactionOutcome{
+		{Processor: "RapidPay_CO", Country: "CO", Currency: "COP", Amount: 50, Status: "approved", Timestamp: timestamppb.New(time.Now().Add(-time.Minute))},
+		{Processor: "RapidPay_CO", Country: "CO", Currency: "COP", Amount: 50, Status: "pending"},
+		{DecisionId: "missing", Country: "CO", Currency: "COP", Amount: 50, Status: "declined"},
+		{Processor: "PayFlow_CO", Country: "CO", Currency: "COP", Amount: 75, Status: "declined"},
+	}
+	for _, outcome := range outcomes {
+		if err := stream.Send(outcome); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Recorded != 2 || response.Rejected != 2 {
+		t.Errorf("Expected 2 recorded and 2 rejected, got %d and %d", response.Recorded, response.Rejected)
+	}
+	if len(response.Errors) != 2 || response.Errors[0].Index != 1 || response.Errors[0].Code != "validation_failed" || response.Errors[1].Code != "decision_not_found" {
+		t.Errorf("Expected validation_failed at 1 and decision_not_found at 2, got %+v", response.Errors)
+	}
+	if count := store.GetTransactionCount(); count != 2 {
+		t.Errorf("Expected 2 transactions in the store, got %d", count)
+	}
+}
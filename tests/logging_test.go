@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"voltarides/smart-router/common/logger"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	middlewareLog "voltarides/smart-router/routers/middleware/log"
+	"voltarides/smart-router/routers/middleware/trace_id"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// captureLogs makes a JSON logger writing to the returned buffer the default until the test ends
+func captureLogs(t *testing.T, level string) *bytes.Buffer {
+	var buf bytes.Buffer
+	l, err := logger.New(&buf, &config.LogConfig{Level: level, ServiceName: "volta-router", Environment: "test"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	previous := slog.Default()
+	slog.SetDefault(l)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &buf
+}
+
+// logEntries decodes one JSON object per line
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected JSON log line, got %q", line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRouteRequestLogsStructuredEvents(t *testing.T) {
+	buf := captureLogs(t, "info")
+
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	controller := controllers.NewRoutingController(service)
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, store.Clock().Now())
+
+	e := echo.New()
+	e.Use(middleware.RequestID())
+	e.Use(middlewareLog.LoggingMiddleware())
+	e.Use(trace_id.TraceIDMiddleware())
+	e.POST("/route", controller.RouteTransaction)
+
+	req := httptest.NewRequest(http.MethodPost, "/route", strings.NewReader(`{"amount":100,"currency":"BRL","country":"BR"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Trace-Id", "trace-123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	entries := logEntries(t, buf)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(entries))
+	}
+
+	decision, access := entries[0], entries[1]
+	if decision["event"] != "routing_decision" || decision["processor"] != "RapidPay_BR" {
+		t.Errorf("Expected routing decision for RapidPay_BR, got %v", decision)
+	}
+	if decision["trace_id"] != "trace-123" {
+		t.Errorf("Expected routing decision to carry trace_id trace-123, got %v", decision["trace_id"])
+	}
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	if requestID == "" || access["request_id"] != requestID || decision["request_id"] != requestID {
+		t.Errorf("Expected request_id %s on both entries, got %v and %v", requestID, decision["request_id"], access["request_id"])
+	}
+
+	expected := map[string]interface{}{
+		"msg":        "http request",
+		"route":      "/route",
+		"status":     float64(200),
+		"trace_id":   "trace-123",
+		"processor":  "RapidPay_BR",
+		"risk_level": "low",
+	}
+	for key, value := range expected {
+		if access[key] != value {
+			t.Errorf("Expected access log %s=%v, got %v", key, value, access[key])
+		}
+	}
+	if _, ok := access["latency_ms"]; !ok {
+		t.Error("Expected access log to include latency_ms")
+	}
+}
+
+func TestLogLevelFiltersEntries(t *testing.T) {
+	buf := captureLogs(t, "warn")
+
+	slog.Info("routine")
+	slog.Warn("unusual")
+
+	entries := logEntries(t, buf)
+	if len(entries) != 1 || entries[0]["msg"] != "unusual" {
+		t.Errorf("Expected only the warn entry, got %v", entries)
+	}
+
+	if _, err := logger.New(buf, &config.LogConfig{Level: "verbose"}); err == nil {
+		t.Error("Expected error for invalid log level, got nil")
+	}
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// counterValue returns the value of a counter in the metrics registry with the given labels
+func counterValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matches := true
+			for _, pair := range metric.GetLabel() {
+				if value, exists := labels[pair.GetName()]; exists && value != pair.GetValue() {
+					matches = false
+				}
+			}
+			if matches {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestRoutingDecisionMetrics(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	labels := map[string]string{"tenant": config.DefaultTenant, "country": "CO", "processor": "RapidPay_CO", "risk_level": "low"}
+	before := counterValue(t, "volta_router_routing_decisions_total", labels)
+	ingestedBefore := counterValue(t, "volta_router_transactions_ingested_total", map[string]string{"tenant": config.DefaultTenant, "country": "CO", "processor": "RapidPay_CO", "status": "approved"})
+
+	addProcessorTransactions(store, "RapidPay_CO", "CO", 9, 10, time.Now().Add(-time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "COP", Country: "CO"}
+	if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.SelectBestProcessor(context.Background(), req, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Simulated decisions are not counted
+	if after := counterValue(t, "volta_router_routing_decisions_total", labels); after-before != 1 {
+		t.Errorf("Expected routing decision counter to increase by 1, got %.0f", after-before)
+	}
+
+	// Only reported outcomes are ingestion; transactions added straight to the store are not
+	if _, err := service.RecordOutcome(models.TransactionOutcomeRequest{Processor: "RapidPay_CO", Country: "CO", Currency: "COP", Amount: 100, Status: "approved"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if after := counterValue(t, "volta_router_transactions_ingested_total", map[string]string{"tenant": config.DefaultTenant, "country": "CO", "processor": "RapidPay_CO", "status": "approved"}); after-ingestedBefore != 1 {
+		t.Errorf("Expected 1 approved transaction ingested, got %.0f", after-ingestedBefore)
+	}
+}
+
+func TestRequestMetricsUseErrorStatus(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+	labels := map[string]string{"method": http.MethodGet, "route": "/volta-router/v1/processors/:name", "status": "404"}
+	before := histogramCount(t, "volta_router_http_request_duration_seconds", labels)
+
+	req := httptest.NewRequest(http.MethodGet, "/volta-router/v1/processors/Unknown_BR", nil)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if after := histogramCount(t, "volta_router_http_request_duration_seconds", labels); after-before != 1 {
+		t.Errorf("Expected the failed request to be labelled 404, got %d more observations", after-before)
+	}
+}
+
+// histogramCount returns the sample count of a histogram series in the metrics registry with exactly the given labels
+func histogramCount(t *testing.T, name string, labels map[string]string) uint64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matches := len(metric.GetLabel()) == len(labels)
+			for _, pair := range metric.GetLabel() {
+				if labels[pair.GetName()] != pair.GetValue() {
+					matches = false
+				}
+			}
+			if matches {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestProcessorCollector(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, time.Now().Add(-time.Minute))
+	store.OpenCircuit(config.DefaultTenant, "PayFlow_BR", "BR")
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.NewProcessorCollector(service))
+
+	expected := `
+# HELP volta_router_processor_approval_rate_percent Approval rate of a processor over the routing time window.
+# TYPE volta_router_processor_approval_rate_percent gauge
+volta_router_processor_approval_rate_percent{country="BR",processor="RapidPay_BR",tenant="rides"} 90
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected+otherProcessorRates()), "volta_router_processor_approval_rate_percent"); err != nil {
+		t.Error(err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	openValue := -1.0
+	for _, family := range families {
+		if family.GetName() != "volta_router_processor_circuit_state" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			values := map[string]string{}
+			for _, pair := range metric.GetLabel() {
+				values[pair.GetName()] = pair.GetValue()
+			}
+			if values["processor"] == "PayFlow_BR" && values["state"] == "open" {
+				openValue = metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	if openValue != 1 {
+		t.Errorf("Expected PayFlow_BR open circuit gauge to be 1, got %.0f", openValue)
+	}
+}
+
+// otherProcessorRates lists the zero approval rates of processors without data
+func otherProcessorRates() string {
+	lines := ""
+	for country, processors := range config.ProcessorsByCountry {
+		for _, processor := range processors {
+			if processor == "RapidPay_BR" {
+				continue
+			}
+			lines += "volta_router_processor_approval_rate_percent{country=\"" + country + "\",processor=\"" + processor + "\",tenant=\"rides\"} 0\n"
+		}
+	}
+	return lines
+}
@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"voltarides/smart-router/alerting"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/openapi"
+	"voltarides/smart-router/ratelimit"
+	"voltarides/smart-router/routers"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+	"voltarides/smart-router/stream"
+	"voltarides/smart-router/webhooks"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newRouter configures an Echo server with every route and middleware of the service,
+// with authentication and rate limiting disabled
+func newRouter(store *storage.InMemoryStore) *echo.Echo {
+	authenticator, _ := auth.NewAuthenticator(&config.SecurityConfig{})
+	return newAuthRouter(store, authenticator, newDisabledLimiter())
+}
+
+// newDisabledLimiter returns a rate limiter that allows every request
+func newDisabledLimiter() *ratelimit.Limiter {
+	limiter, _ := ratelimit.NewLimiter(&config.RateLimitConfig{Default: config.RateLimit{Rate: 1, Burst: 1}})
+	return limiter
+}
+
+// newAuthRouter configures an Echo server with every route and middleware of the service
+func newAuthRouter(store *storage.InMemoryStore, authenticator *auth.Authenticator, limiter *ratelimit.Limiter) *echo.Echo {
+	return newDataRouter(store, authenticator, limiter, controllers.NewDataController(store, "development", config.GetSnapshotConfig()))
+}
+
+// newDataRouter configures an Echo server with every route and middleware of the service and the given data controller
+func newDataRouter(store *storage.InMemoryStore, authenticator *auth.Authenticator, limiter *ratelimit.Limiter, dataController *controllers.DataController) *echo.Echo {
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	streamConfig := config.GetStreamConfig()
+
+	e := echo.New()
+	routers.ConfigRouter(e, authenticator, limiter, nil,
+		controllers.NewRoutingController(service),
+		dataController,
+		controllers.NewCircuitController(service),
+		controllers.NewAlertController(alerting.NewDetector(store, config.GetAnomalyConfig())),
+		controllers.NewWebhookController(webhooks.NewDispatcher()),
+		controllers.NewStreamController([]*stream.Hub{stream.NewHub(service, streamConfig)}, streamConfig.Heartbeat),
+	)
+	return e
+}
+
+func TestOpenAPIDocumentIsValid(t *testing.T) {
+	if err := openapi.Document().Validate(context.Background()); err != nil {
+		t.Fatalf("Expected a valid OpenAPI document, got %v", err)
+	}
+
+	schemas := openapi.Document().Components.Schemas
+	request, ok := schemas["RoutingRequest"]
+	if !ok {
+		t.Fatal("Expected RoutingRequest schema in components")
+	}
+	if len(request.Value.Required) != 3 {
+		t.Errorf("Expected 3 required RoutingRequest fields, got %v", request.Value.Required)
+	}
+	if country := request.Value.Properties["country"].Value; country.MinLength != 2 || country.MaxLength == nil || *country.MaxLength != 2 {
+		t.Errorf("Expected country length 2 from the validate tag, got %d-%v", country.MinLength, country.MaxLength)
+	}
+
+	// Embedded structs are flattened into the parent schema
+	if _, ok := schemas["ProcessorEffectiveness"].Value.Properties["regret"]; !ok {
+		t.Error("Expected ProcessorEffectiveness to include the embedded RoutingEffectiveness fields")
+	}
+}
+
+func TestOpenAPICoversEveryRoute(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+	doc := openapi.Document()
+
+	registered := make(map[string]bool)
+	for _, route := range e.Routes() {
+		registered[route.Method+" "+route.Path] = true
+
+		item := doc.Paths.Value(openapi.SpecPath(route.Path))
+		if item == nil || item.GetOperation(route.Method) == nil {
+			t.Errorf("Expected %s %s in the OpenAPI document", route.Method, route.Path)
+		}
+	}
+
+	for _, op := range openapi.Operations() {
+		if !registered[op.Method+" "+op.Path] {
+			t.Errorf("Expected documented operation %s %s to be registered", op.Method, op.Path)
+		}
+	}
+}
+
+func TestOpenAPIEndpoint(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Expected JSON document, got %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("Expected OpenAPI 3 document, got %s", doc.OpenAPI)
+	}
+	if _, ok := doc.Paths["/volta-router/v1/processors/{name}"]; !ok {
+		t.Error("Expected /volta-router/v1/processors/{name} in paths")
+	}
+}
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"out of range field", http.MethodPost, "/volta-router/v1/route", `{"amount": 0, "currency": "BRL", "country": "BR"}`, http.StatusBadRequest, "validation_failed"},
+		{"missing field", http.MethodPost, "/volta-router/v1/route", `{"amount": 10, "country": "BR"}`, http.StatusBadRequest, "validation_failed"},
+		{"malformed body", http.MethodPost, "/volta-router/v1/route", `{"amount": 10,`, http.StatusBadRequest, "invalid_request"},
+		{"invalid boolean query", http.MethodPost, "/volta-router/v1/route?simulate=maybe", `{"amount": 10, "currency": "BRL", "country": "BR"}`, http.StatusBadRequest, "invalid_query"},
+		{"invalid timestamp query", http.MethodGet, "/volta-router/v1/routing/decisions?from=yesterday", "", http.StatusBadRequest, "invalid_query"},
+		{"invalid enum query", http.MethodGet, "/volta-router/v1/alerts?status=open", "", http.StatusBadRequest, "invalid_query"},
+		{"valid request reaches handler", http.MethodPost, "/volta-router/v1/route", `{"amount": 10, "currency": "USD", "country": "US"}`, http.StatusBadRequest, "unsupported_country"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		if tt.body != "" {
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var response models.ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if rec.Code != tt.status || response.Error != tt.code {
+			t.Errorf("%s: expected %d %s, got %d %s (%s)", tt.name, tt.status, tt.code, rec.Code, response.Error, response.Message)
+		}
+	}
+}
+
+func TestRequestValidationKeepsBatchItemErrors(t *testing.T) {
+	e := newRouter(storage.NewInMemoryStore())
+
+	body := `[{"amount": 0, "currency": "BRL", "country": "BR"}, {"amount": 10, "currency": "USD", "country": "US"}]`
+	req := httptest.NewRequest(http.MethodPost, "/volta-router/v1/route/batch", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 with per-item errors, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response models.BatchRoutingResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response.Failed != 2 {
+		t.Errorf("Expected 2 failed items, got %d", response.Failed)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"testing"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+func TestProcessorTimeSeriesBuckets(t *testing.T) {
+	base := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+	store := storage.NewInMemoryStore(storage.WithClock(clock.NewSimulated(base.Add(10 * time.Minute))))
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 3, 4, base.Add(30*time.Second))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 1, 4, base.Add(2*time.Minute+10*time.Second))
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 4, 4, base.Add(30*time.Second))
+
+	series, err := service.GetProcessorTimeSeries("PayFlow_BR", base, base.Add(3*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if series.Country != "BR" || len(series.Buckets) != 4 {
+		t.Fatalf("Expected 4 BR buckets, got %d for %s", len(series.Buckets), series.Country)
+	}
+
+	first := series.Buckets[0]
+	if first.Approved != 3 || first.Declined != 1 || first.ApprovalRate == nil || *first.ApprovalRate != 75.0 {
+		t.Errorf("Expected 3 approved, 1 declined at 75%% in the first bucket, got %+v", first)
+	}
+	if series.Buckets[1].Total != 0 || series.Buckets[1].ApprovalRate != nil {
+		t.Errorf("Expected an empty second bucket with no rate, got %+v", series.Buckets[1])
+	}
+	if rate := series.Buckets[2].ApprovalRate; rate == nil || *rate != 25.0 {
+		t.Errorf("Expected 25%% in the third bucket, got %v", rate)
+	}
+
+	if _, err := service.GetProcessorTimeSeries("Unknown_BR", time.Time{}, time.Time{}, 0); err == nil {
+		t.Error("Expected error for unknown processor")
+	}
+	if _, err := service.GetProcessorTimeSeries("PayFlow_BR", base, base.Add(time.Hour), time.Millisecond); err == nil {
+		t.Error("Expected error for sub-second bucket")
+	}
+}
+
+func TestProcessorTimeSeriesCircuitOverlay(t *testing.T) {
+	base := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+	store := storage.NewInMemoryStore(storage.WithClock(clock.NewSimulated(base.Add(time.Hour))))
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	// Opened automatically at 14:02, left alone past the breaker timeout, then closed at 14:12
+	store.RecordCircuitEvent(models.CircuitEvent{Processor: "PayFlow_BR", Country: "BR", FromState: models.CircuitClosed, ToState: models.CircuitOpen, Trigger: models.CircuitTriggerLowApprovalRate, Timestamp: base.Add(2 * time.Minute)})
+	store.RecordCircuitEvent(models.CircuitEvent{Processor: "PayFlow_BR", Country: "BR", FromState: models.CircuitHalfOpen, ToState: models.CircuitClosed, Trigger: models.CircuitTriggerRecovered, Timestamp: base.Add(12 * time.Minute)})
+
+	series, err := service.GetProcessorTimeSeries("PayFlow_BR", base, base.Add(15*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		state models.CircuitState
+		from  time.Duration
+		to    time.Duration
+	}{
+		{models.CircuitClosed, 0, 2 * time.Minute},
+		{models.CircuitOpen, 2 * time.Minute, 2*time.Minute + cfg.CircuitBreakerTimeout},
+		{models.CircuitHalfOpen, 2*time.Minute + cfg.CircuitBreakerTimeout, 12 * time.Minute},
+		{models.CircuitClosed, 12 * time.Minute, 15 * time.Minute},
+	}
+
+	if len(series.CircuitStates) != len(expected) {
+		t.Fatalf("Expected %d circuit intervals, got %+v", len(expected), series.CircuitStates)
+	}
+	for i, want := range expected {
+		got := series.CircuitStates[i]
+		if got.State != want.state || !got.From.Equal(base.Add(want.from)) || !got.To.Equal(base.Add(want.to)) {
+			t.Errorf("Expected interval %d to be %s from +%v to +%v, got %s from %v to %v", i, want.state, want.from, want.to, got.State, got.From, got.To)
+		}
+	}
+	if series.CircuitStates[1].Trigger != models.CircuitTriggerLowApprovalRate {
+		t.Errorf("Expected open interval trigger %s, got %s", models.CircuitTriggerLowApprovalRate, series.CircuitStates[1].Trigger)
+	}
+
+	// The bucket ending at 14:03 is covered by the open interval
+	if series.Buckets[2].CircuitState != models.CircuitOpen {
+		t.Errorf("Expected bucket 2 circuit state open, got %s", series.Buckets[2].CircuitState)
+	}
+}
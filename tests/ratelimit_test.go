@@ -0,0 +1,253 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/common/clock"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/grpcapi"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/ratelimit"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newTestLimiter enables rate limiting against a simulated clock
+func newTestLimiter(t *testing.T, cfg *config.RateLimitConfig) (*ratelimit.Limiter, *clock.Simulated) {
+	t.Helper()
+
+	clk := clock.NewSimulated(time.Date(2024, 2, 26, 12, 0, 0, 0, time.UTC))
+	cfg.Enabled = true
+	limiter, err := ratelimit.NewLimiter(cfg, ratelimit.WithClock(clk))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return limiter, clk
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	limiter, clk := newTestLimiter(t, &config.RateLimitConfig{
+		Default: config.RateLimit{Rate: 100, Burst: 100},
+		Routes:  map[string]config.RateLimit{"/route": {Rate: 2, Burst: 3}},
+	})
+
+	for i := 0; i < 3; i++ {
+		if decision := limiter.Allow("checkout", "", "/route"); !decision.Allowed {
+			t.Fatalf("Expected request %d within the burst to be allowed", i+1)
+		}
+	}
+
+	decision := limiter.Allow("checkout", "", "/route")
+	if decision.Allowed {
+		t.Fatal("Expected the request after the burst to be rejected")
+	}
+	if decision.Scope != ratelimit.ScopeRoute {
+		t.Errorf("Expected scope route, got %s", decision.Scope)
+	}
+	if decision.RetryAfter != 500*time.Millisecond {
+		t.Errorf("Expected retry after 500ms, got %s", decision.RetryAfter)
+	}
+	if decision.RetryAfterSeconds() != 1 {
+		t.Errorf("Expected Retry-After of 1 second, got %d", decision.RetryAfterSeconds())
+	}
+
+	// Other routes and other callers have their own buckets
+	if !limiter.Allow("checkout", "", "/processors").Allowed {
+		t.Error("Expected a route without a limit to be allowed")
+	}
+	if !limiter.Allow("ledger", "", "/route").Allowed {
+		t.Error("Expected another client to be allowed")
+	}
+
+	clk.Advance(500 * time.Millisecond)
+	if !limiter.Allow("checkout", "", "/route").Allowed {
+		t.Error("Expected a request to be allowed once a token was refilled")
+	}
+	if limiter.Allow("checkout", "", "/route").Allowed {
+		t.Error("Expected the refilled token to be used up")
+	}
+}
+
+func TestRateLimiterClientQuota(t *testing.T) {
+	limiter, _ := newTestLimiter(t, &config.RateLimitConfig{
+		Default: config.RateLimit{Rate: 1, Burst: 2},
+		Clients: map[string]config.RateLimit{"checkout": {Rate: 10, Burst: 5}},
+		Routes:  map[string]config.RateLimit{"/route": {Rate: 1, Burst: 1}},
+	})
+
+	// The quota covers every route of the caller
+	limiter.Allow("ledger", "", "/processors")
+	limiter.Allow("ledger", "", "/routing/stats")
+	decision := limiter.Allow("ledger", "", "/alerts")
+	if decision.Allowed || decision.Scope != ratelimit.ScopeClient {
+		t.Errorf("Expected the default quota to reject the third request, got %+v", decision)
+	}
+
+	// A rejected request takes no token, so the route limit does not use up the quota
+	limiter.Allow("checkout", "", "/route")
+	for i := 0; i < 3; i++ {
+		limiter.Allow("checkout", "", "/route")
+	}
+	for i := 0; i < 4; i++ {
+		if !limiter.Allow("checkout", "", "/processors").Allowed {
+			t.Errorf("Expected request %d within the client's own quota to be allowed", i+1)
+		}
+	}
+
+	// Anonymous callers are limited per IP address
+	limiter.Allow("", "10.0.0.1", "/processors")
+	limiter.Allow("", "10.0.0.1", "/processors")
+	if limiter.Allow("", "10.0.0.1", "/processors").Allowed {
+		t.Error("Expected the IP address to be over its quota")
+	}
+	if !limiter.Allow("", "10.0.0.2", "/processors").Allowed {
+		t.Error("Expected another IP address to be allowed")
+	}
+}
+
+func TestRateLimiterDropsIdleBuckets(t *testing.T) {
+	limiter, clk := newTestLimiter(t, &config.RateLimitConfig{Default: config.RateLimit{Rate: 1, Burst: 5}})
+
+	limiter.Allow("", "10.0.0.1", "/processors")
+	limiter.Allow("", "10.0.0.2", "/processors")
+	if limiter.Buckets() != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", limiter.Buckets())
+	}
+
+	clk.Advance(2 * time.Minute)
+	limiter.Allow("", "10.0.0.3", "/processors")
+	if limiter.Buckets() != 1 {
+		t.Errorf("Expected refilled buckets to be dropped, got %d buckets", limiter.Buckets())
+	}
+}
+
+func TestNewLimiterRejectsInvalidLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.RateLimitConfig
+	}{
+		{"zero default", &config.RateLimitConfig{}},
+		{"zero burst", &config.RateLimitConfig{
+			Default: config.RateLimit{Rate: 1, Burst: 1},
+			Routes:  map[string]config.RateLimit{"/route": {Rate: 5}},
+		}},
+		{"negative client rate", &config.RateLimitConfig{
+			Default: config.RateLimit{Rate: 1, Burst: 1},
+			Clients: map[string]config.RateLimit{"checkout": {Rate: -1, Burst: 1}},
+		}},
+	}
+
+	for _, tt := range tests {
+		if _, err := ratelimit.NewLimiter(tt.cfg); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestRateLimitConfigFromEnvironment(t *testing.T) {
+	t.Setenv("RATE_LIMIT_DEFAULT", "50:80")
+	t.Setenv("RATE_LIMIT_CLIENTS", "checkout=500:1000, ledger=20")
+	t.Setenv("RATE_LIMIT_ROUTES", "/route=5:10,/route/what-if=abc")
+
+	cfg := config.GetRateLimitConfig()
+	if !cfg.Enabled {
+		t.Error("Expected rate limiting to be enabled by default")
+	}
+	if cfg.Default != (config.RateLimit{Rate: 50, Burst: 80}) {
+		t.Errorf("Expected default 50:80, got %+v", cfg.Default)
+	}
+	if cfg.Clients["ledger"] != (config.RateLimit{Rate: 20, Burst: 20}) {
+		t.Errorf("Expected the burst to default to the rate, got %+v", cfg.Clients["ledger"])
+	}
+	if cfg.Routes["/route"] != (config.RateLimit{Rate: 5, Burst: 10}) {
+		t.Errorf("Expected /route 5:10, got %+v", cfg.Routes["/route"])
+	}
+	if _, ok := cfg.Routes["/route/batch"]; !ok {
+		t.Error("Expected the default /route/batch limit to be kept")
+	}
+	if _, err := ratelimit.NewLimiter(cfg); err == nil {
+		t.Error("Expected the malformed /route/what-if limit to be rejected")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter, _ := newTestLimiter(t, &config.RateLimitConfig{
+		Default: config.RateLimit{Rate: 100, Burst: 100},
+		Routes:  map[string]config.RateLimit{"/route": {Rate: 0.5, Burst: 1}},
+	})
+	e := newAuthRouter(storage.NewInMemoryStore(), newTestAuthenticator(t), limiter)
+
+	route := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/volta-router/v1/route", strings.NewReader(`{"amount": 100, "currency": "BRL", "country": "BR"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", "router-key")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := route(); rec.Code == http.StatusTooManyRequests {
+		t.Fatal("Expected the first request to be allowed")
+	}
+
+	rec := route()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", rec.Header().Get("Retry-After"))
+	}
+	var body models.ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if body.Error != "rate_limited" {
+		t.Errorf("Expected error code rate_limited, got %s", body.Error)
+	}
+
+	// Root-level endpoints are not limited
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected health check status 200, got %d", rec.Code)
+		}
+	}
+}
+
+func TestGRPCRateLimit(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	limiter, _ := newTestLimiter(t, &config.RateLimitConfig{
+		Default: config.RateLimit{Rate: 100, Burst: 100},
+		Routes:  map[string]config.RateLimit{"/routing/stats": {Rate: 1, Burst: 1}},
+	})
+	service := services.NewRoutingService(storage.NewInMemoryStore(), config.GetRoutingConfig())
+
+	client := serveTestRouter(t, service, grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.UnaryAuthInterceptor(authenticator), grpcapi.UnaryRateLimitInterceptor(limiter)),
+		grpc.ChainStreamInterceptor(grpcapi.StreamAuthInterceptor(authenticator), grpcapi.StreamRateLimitInterceptor(limiter)),
+	))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "router-key")
+
+	if _, err := client.GetRoutingStats(ctx, &routerpb.GetRoutingStatsRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var trailer metadata.MD
+	_, err := client.GetRoutingStats(ctx, &routerpb.GetRoutingStatsRequest{}, grpc.Trailer(&trailer))
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Fatalf("Expected code ResourceExhausted, got %s (%v)", code, err)
+	}
+	if values := trailer.Get("retry-after"); len(values) != 1 || values[0] != "1" {
+		t.Errorf("Expected retry-after trailer 1, got %v", values)
+	}
+}
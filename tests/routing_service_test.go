@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"testing"
 	"time"
 	"voltarides/smart-router/config"
@@ -123,7 +124,7 @@ func TestSelectBestProcessor(t *testing.T) {
 		Country:  "BR",
 	}
 
-	response, err := service.SelectBestProcessor(req, false)
+	response, err := service.SelectBestProcessor(context.Background(), req, false)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -153,7 +154,7 @@ func TestSelectBestProcessorUnsupportedCountry(t *testing.T) {
 		Country:  "US",
 	}
 
-	_, err := service.SelectBestProcessor(req, false)
+	_, err := service.SelectBestProcessor(context.Background(), req, false)
 	if err == nil {
 		t.Fatal("Expected error for unsupported country, got nil")
 	}
@@ -176,7 +177,7 @@ func TestSelectBestProcessorNoData(t *testing.T) {
 		Country:  "BR",
 	}
 
-	_, err := service.SelectBestProcessor(req, false)
+	_, err := service.SelectBestProcessor(context.Background(), req, false)
 	if err == nil {
 		t.Fatal("Expected error when no data available, got nil")
 	}
@@ -241,7 +242,7 @@ func TestRiskLevelClassification(t *testing.T) {
 				config.ProcessorsByCountry["BR"] = originalProcessors
 			}()
 
-			response, err := service.SelectBestProcessor(req, false)
+			response, err := service.SelectBestProcessor(context.Background(), req, false)
 			if err != nil {
 				t.Fatalf("Expected no error, got %v", err)
 			}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+// recordDecision records a routing decision made at the given time
+func recordDecision(store *storage.InMemoryStore, id, processor, country, risk string, rate float64, at time.Time) {
+	store.RecordRoutingDecision(models.RoutingDecision{
+		ID:           id,
+		Processor:    processor,
+		Country:      country,
+		ApprovalRate: rate,
+		RiskLevel:    risk,
+		Timestamp:    at.Format(time.RFC3339),
+	})
+}
+
+func TestRoutingStatsBreakdowns(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	base := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+
+	recordDecision(store, "d1", "RapidPay_BR", "BR", "low", 90.0, base)
+	recordDecision(store, "d2", "RapidPay_BR", "BR", "low", 86.0, base.Add(30*time.Second))
+	recordDecision(store, "d3", "TurboAcquire_BR", "BR", "medium", 75.0, base.Add(3*time.Minute))
+	recordDecision(store, "d4", "PayFlow_MX", "MX", "high", 65.0, base.Add(3*time.Minute))
+
+	store.AddTransaction(models.Transaction{ID: "o1", DecisionID: "d1", Processor: "RapidPay_BR", Country: "BR", Status: "approved", Timestamp: base})
+	store.AddTransaction(models.Transaction{ID: "o2", DecisionID: "d2", Processor: "TurboAcquire_BR", Country: "BR", Status: "approved", Timestamp: base})
+
+	stats, err := service.GetRoutingStats(models.RoutingStatsQuery{Country: "BR"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if stats.TotalDecisions != 4 || stats.Decisions != 3 {
+		t.Errorf("Expected 3 of 4 decisions, got %d of %d", stats.Decisions, stats.TotalDecisions)
+	}
+	if stats.Window != "last_50_decisions" {
+		t.Errorf("Expected default window last_50_decisions, got %s", stats.Window)
+	}
+	if stats.Distribution["RapidPay_BR"] != 2 || stats.RiskLevels["medium"] != 1 {
+		t.Errorf("Expected 2 RapidPay_BR decisions and 1 medium risk, got %v and %v", stats.Distribution, stats.RiskLevels)
+	}
+	if share := stats.Share["RapidPay_BR"]; share < 66.6 || share > 66.7 {
+		t.Errorf("Expected RapidPay_BR share of 66.67%%, got %.2f", share)
+	}
+	if stats.AvgApprovalRate["RapidPay_BR"] != 88.0 {
+		t.Errorf("Expected average approval rate 88.0, got %.2f", stats.AvgApprovalRate["RapidPay_BR"])
+	}
+	if stats.Fallback.DecisionsWithOutcome != 2 || stats.Fallback.FallbackUsed != 1 || stats.Fallback.Rate != 50.0 {
+		t.Errorf("Expected 1 of 2 decisions to use the fallback, got %+v", stats.Fallback)
+	}
+
+	// Three minutes of decisions fall into four one-minute buckets
+	if stats.Bucket != "1m0s" || len(stats.TimeSeries) != 4 {
+		t.Fatalf("Expected 4 buckets of 1m0s, got %d of %s", len(stats.TimeSeries), stats.Bucket)
+	}
+	if stats.TimeSeries[0].Decisions != 2 || stats.TimeSeries[1].Decisions != 0 || stats.TimeSeries[3].Distribution["TurboAcquire_BR"] != 1 {
+		t.Errorf("Unexpected time series %+v", stats.TimeSeries)
+	}
+}
+
+func TestRoutingStatsQueryFilters(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	base := time.Date(2024, 2, 26, 14, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 10; i++ {
+		recordDecision(store, fmt.Sprintf("d%d", i), "RapidPay_BR", "BR", "low", 90.0, base.Add(time.Duration(i)*time.Minute))
+	}
+
+	limited, err := service.GetRoutingStats(models.RoutingStatsQuery{Limit: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if limited.Decisions != 3 || limited.Window != "last_3_decisions" {
+		t.Errorf("Expected last 3 decisions, got %d (%s)", limited.Decisions, limited.Window)
+	}
+	if !limited.From.Equal(base.Add(7 * time.Minute)) {
+		t.Errorf("Expected series to start at the 8th decision, got %v", limited.From)
+	}
+
+	ranged, err := service.GetRoutingStats(models.RoutingStatsQuery{From: base.Add(2 * time.Minute), To: base.Add(5 * time.Minute), Bucket: 2 * time.Minute})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ranged.Decisions != 4 || ranged.Window != "time_range" {
+		t.Errorf("Expected 4 decisions in range, got %d (%s)", ranged.Decisions, ranged.Window)
+	}
+	if len(ranged.TimeSeries) != 2 {
+		t.Errorf("Expected 2 buckets of 2m, got %d", len(ranged.TimeSeries))
+	}
+
+	if _, err := service.GetRoutingStats(models.RoutingStatsQuery{Bucket: time.Second, From: base, To: base.Add(24 * time.Hour)}); err == nil {
+		t.Error("Expected error when the bucket yields too many buckets")
+	}
+	if _, err := service.GetRoutingStats(models.RoutingStatsQuery{From: base, To: base.Add(-time.Minute)}); err == nil {
+		t.Error("Expected error when to is before from")
+	}
+}
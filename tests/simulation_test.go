@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+func TestSimulationDoesNotOpenCircuits(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	response, err := service.SelectBestProcessor(context.Background(), req, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The store must be untouched
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", cfg.CircuitBreakerTimeout); state != models.CircuitClosed {
+		t.Errorf("Expected PayFlow_BR circuit to stay closed in simulation, got %s", state)
+	}
+	if events := store.GetCircuitEvents(config.DefaultTenant, "", "", time.Time{}, time.Time{}); len(events) != 0 {
+		t.Errorf("Expected no circuit events in simulation, got %d", len(events))
+	}
+	if count := store.CountRoutingDecisions(config.DefaultTenant); count != 0 {
+		t.Errorf("Expected no routing decisions in simulation, got %d", count)
+	}
+
+	// The would-be transition is reported instead
+	if len(response.SimulatedTransitions) != 1 {
+		t.Fatalf("Expected 1 simulated transition, got %d", len(response.SimulatedTransitions))
+	}
+	transition := response.SimulatedTransitions[0]
+	if transition.Processor != "PayFlow_BR" || transition.ToState != models.CircuitOpen {
+		t.Errorf("Expected PayFlow_BR to open, got %s -> %s", transition.Processor, transition.ToState)
+	}
+}
+
+func TestLiveRoutingAppliesTransitions(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	response, err := service.SelectBestProcessor(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(response.SimulatedTransitions) != 0 {
+		t.Errorf("Expected no simulated transitions outside simulation, got %d", len(response.SimulatedTransitions))
+	}
+	if state := store.GetCircuitState(config.DefaultTenant, "PayFlow_BR", "BR", cfg.CircuitBreakerTimeout); state != models.CircuitOpen {
+		t.Errorf("Expected PayFlow_BR circuit to open on a live request, got %s", state)
+	}
+	if count := store.CountRoutingDecisions(config.DefaultTenant); count != 1 {
+		t.Errorf("Expected 1 routing decision, got %d", count)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"testing"
 	"time"
+	"voltarides/smart-router/config"
 	"voltarides/smart-router/models"
 	"voltarides/smart-router/storage"
 )
@@ -74,7 +75,7 @@ func TestConcurrentReadWrite(t *testing.T) {
 			defer wg.Done()
 			// Read operations
 			for j := 0; j < 100; j++ {
-				_ = store.GetTransactionsByWindow("RapidPay_BR", "BR", 15*time.Minute)
+				_ = store.GetTransactionsByWindow(config.DefaultTenant, "RapidPay_BR", "BR", 15*time.Minute)
 				_ = store.GetTransactionCount()
 				_ = store.GetAllTransactions()
 			}
@@ -120,7 +121,7 @@ func TestGetTransactionsByWindow(t *testing.T) {
 	store.AddTransactions(transactions)
 
 	// Query for BR transactions within 15 minutes
-	results := store.GetTransactionsByWindow("RapidPay_BR", "BR", 15*time.Minute)
+	results := store.GetTransactionsByWindow(config.DefaultTenant, "RapidPay_BR", "BR", 15*time.Minute)
 
 	// Should only get 2 transactions (tx1, tx2)
 	if len(results) != 2 {
@@ -150,20 +151,17 @@ func TestRoutingDecisionTracking(t *testing.T) {
 		store.RecordRoutingDecision(decision)
 	}
 
-	// Get stats for last 10 decisions
-	stats := store.GetRoutingStats(10)
-
-	// Verify distribution
-	if stats["RapidPay_BR"] != 3 {
-		t.Errorf("Expected 3 decisions for RapidPay_BR, got %d", stats["RapidPay_BR"])
+	// Get the last 3 decisions, oldest first
+	recent := store.GetRecentDecisionOutcomes(config.DefaultTenant, "BR", 3)
+	if len(recent) != 3 {
+		t.Fatalf("Expected 3 recent decisions, got %d", len(recent))
 	}
-
-	if stats["TurboAcquire_BR"] != 1 {
-		t.Errorf("Expected 1 decision for TurboAcquire_BR, got %d", stats["TurboAcquire_BR"])
+	if recent[0].Decision.ApprovalRate != 91.0 || recent[2].Decision.ApprovalRate != 93.0 {
+		t.Errorf("Expected the last 3 decisions oldest first, got %v", recent)
 	}
 
 	// Verify total count
-	totalCount := store.GetRoutingDecisionCount()
+	totalCount := store.CountRoutingDecisions(config.DefaultTenant)
 	if totalCount != 4 {
 		t.Errorf("Expected 4 total decisions, got %d", totalCount)
 	}
@@ -193,7 +191,7 @@ func TestStoreClear(t *testing.T) {
 	if store.GetTransactionCount() == 0 {
 		t.Fatal("Expected transactions to be added")
 	}
-	if store.GetRoutingDecisionCount() == 0 {
+	if store.CountRoutingDecisions(config.DefaultTenant) == 0 {
 		t.Fatal("Expected routing decisions to be added")
 	}
 
@@ -204,8 +202,8 @@ func TestStoreClear(t *testing.T) {
 	if store.GetTransactionCount() != 0 {
 		t.Errorf("Expected 0 transactions after clear, got %d", store.GetTransactionCount())
 	}
-	if store.GetRoutingDecisionCount() != 0 {
-		t.Errorf("Expected 0 routing decisions after clear, got %d", store.GetRoutingDecisionCount())
+	if store.CountRoutingDecisions(config.DefaultTenant) != 0 {
+		t.Errorf("Expected 0 routing decisions after clear, got %d", store.CountRoutingDecisions(config.DefaultTenant))
 	}
 }
 
@@ -233,8 +231,30 @@ func TestConcurrentRoutingDecisions(t *testing.T) {
 	wg.Wait()
 
 	// Verify count
-	count := store.GetRoutingDecisionCount()
+	count := store.CountRoutingDecisions(config.DefaultTenant)
 	if count != numGoroutines {
 		t.Errorf("Expected %d routing decisions, got %d (possible race condition)", numGoroutines, count)
 	}
 }
+
+func TestRoutingDecisionCountsByTenant(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	now := time.Now().Format(time.RFC3339)
+
+	store.RecordRoutingDecision(models.RoutingDecision{Processor: "RapidPay_BR", Country: "BR", Timestamp: now})
+	store.RecordRoutingDecision(models.RoutingDecision{Tenant: "food", Processor: "PayFlow_BR", Country: "BR", Timestamp: now})
+	store.RecordRoutingDecision(models.RoutingDecision{Tenant: "food", Processor: "PayFlow_BR", Country: "BR", Timestamp: now})
+
+	if count := store.CountRoutingDecisions(config.DefaultTenant); count != 1 {
+		t.Errorf("Expected 1 decision for the default tenant, got %d", count)
+	}
+	if count := store.CountRoutingDecisions("food"); count != 2 {
+		t.Errorf("Expected 2 decisions for food, got %d", count)
+	}
+
+	// The last N decisions are the tenant's own, however many other tenants made since
+	recent := store.GetRecentDecisionOutcomes(config.DefaultTenant, "", 1)
+	if len(recent) != 1 || recent[0].Decision.Processor != "RapidPay_BR" {
+		t.Errorf("Expected only the default tenant's decision, got %v", recent)
+	}
+}
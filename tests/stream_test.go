@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/controllers"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+	"voltarides/smart-router/stream"
+
+	"github.com/labstack/echo/v4"
+)
+
+// countMessages counts messages by type
+func countMessages(messages []stream.Message) map[string]int {
+	counts := make(map[string]int)
+	for _, message := range messages {
+		counts[message.Type]++
+	}
+	return counts
+}
+
+func TestHubPollBroadcastsOnlyChanges(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	hub := stream.NewHub(service, config.GetStreamConfig())
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+
+	// The first poll reports everything, the second nothing
+	first := countMessages(hub.Poll())
+	if first[models.StreamProcessorStats] != 9 || first[models.StreamRoutingDistribution] != 3 {
+		t.Errorf("Expected 9 processor stats and 3 distributions on the first poll, got %v", first)
+	}
+	if changes := hub.Poll(); len(changes) != 0 {
+		t.Errorf("Expected no changes on an unchanged poll, got %d", len(changes))
+	}
+
+	brazil, unsubscribeBR := hub.Subscribe("BR")
+	defer unsubscribeBR()
+	mexico, unsubscribeMX := hub.Subscribe("MX")
+	defer unsubscribeMX()
+
+	// New transactions change one processor's stats; routing changes the distribution
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 6, 10, now.Add(-time.Minute))
+	if _, err := service.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	changes := hub.Poll()
+	counts := countMessages(changes)
+	if counts[models.StreamProcessorStats] != 1 || counts[models.StreamRoutingDistribution] != 1 {
+		t.Fatalf("Expected 1 processor stats change and 1 distribution change, got %v", counts)
+	}
+	for _, change := range changes {
+		if change.Type == models.StreamProcessorStats {
+			if stat := change.Data.(models.ProcessorStats); stat.Name != "RapidPay_BR" || stat.TransactionCount != 20 {
+				t.Errorf("Expected RapidPay_BR with 20 transactions, got %s with %d", stat.Name, stat.TransactionCount)
+			}
+		}
+	}
+
+	if len(brazil) != 2 {
+		t.Errorf("Expected 2 messages for the BR subscriber, got %d", len(brazil))
+	}
+	if len(mexico) != 0 {
+		t.Errorf("Expected no messages for the MX subscriber, got %d", len(mexico))
+	}
+}
+
+func TestHubForwardsRouterEvents(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	bus := events.NewBus()
+	service := services.NewRoutingService(store, config.GetRoutingConfig(), services.WithEventBus(bus))
+	hub := stream.NewHub(service, config.GetStreamConfig())
+	bus.Subscribe(hub.Handle)
+
+	messages, unsubscribe := hub.Subscribe("BR")
+	defer unsubscribe()
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 5, 10, now.Add(-5*time.Minute))
+
+	if _, err := service.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case message := <-messages:
+		event := message.Data.(events.Event)
+		if message.Type != events.CircuitOpened || event.Data.(models.CircuitEvent).Processor != "PayFlow_BR" {
+			t.Errorf("Expected circuit.opened for PayFlow_BR, got %s with %+v", message.Type, event.Data)
+		}
+	default:
+		t.Fatal("Expected a circuit transition to be forwarded")
+	}
+
+	// Unsubscribing closes the stream
+	unsubscribe()
+	if _, open := <-messages; open {
+		t.Error("Expected the message channel to be closed after unsubscribing")
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	hub := stream.NewHub(service, config.GetStreamConfig())
+
+	messages, unsubscribe := hub.Subscribe("")
+	defer unsubscribe()
+
+	// Never read: the buffer fills and the subscriber is disconnected
+	for i := 0; i < 100; i++ {
+		hub.Handle(events.New(events.CircuitClosed, time.Now(), models.CircuitEvent{Country: "BR"}))
+	}
+
+	received := 0
+	for range messages {
+		received++
+	}
+	if received == 0 || received >= 100 {
+		t.Errorf("Expected the subscriber to be dropped after a partial backlog, got %d messages", received)
+	}
+}
+
+func TestHubIgnoresOtherTenantsEvents(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	hub := stream.NewHub(service, config.GetStreamConfig())
+
+	messages, unsubscribe := hub.Subscribe("")
+	defer unsubscribe()
+
+	foreign := events.New(events.CountryHighRisk, time.Now(), map[string]string{"country": "BR"})
+	foreign.Tenant = "food"
+	hub.Handle(foreign)
+	hub.Handle(events.New(events.CircuitClosed, time.Now(), models.CircuitEvent{Country: "BR"}))
+
+	select {
+	case message := <-messages:
+		if message.Type != events.CircuitClosed {
+			t.Errorf("Expected only the default tenant's %s event, got %s", events.CircuitClosed, message.Type)
+		}
+	default:
+		t.Fatal("Expected the default tenant's event to be forwarded")
+	}
+	select {
+	case message := <-messages:
+		t.Errorf("Expected no further messages, got %s", message.Type)
+	default:
+	}
+}
+
+func TestProcessorStreamEndpoint(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+	hub := stream.NewHub(service, config.GetStreamConfig())
+	controller := controllers.NewStreamController([]*stream.Hub{hub}, 20*time.Millisecond)
+
+	e := echo.New()
+	e.GET("/processors/stream", controller.StreamProcessorHealth)
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/processors/stream?country=XX")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unsupported country, got %d", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/processors/stream?country=BR", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get(echo.HeaderContentType); contentType != "text/event-stream" {
+		t.Errorf("Expected content type text/event-stream, got %s", contentType)
+	}
+
+	// Snapshot first (3 BR processors and the BR distribution), then heartbeats
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() && seen[models.StreamHeartbeat] == 0 {
+		if event, found := strings.CutPrefix(scanner.Text(), "event: "); found {
+			seen[event]++
+		}
+		if data, found := strings.CutPrefix(scanner.Text(), "data: "); found && strings.Contains(data, `"country":"MX"`) {
+			t.Errorf("Expected only BR updates, got %s", data)
+		}
+	}
+
+	if seen[models.StreamProcessorStats] != 3 || seen[models.StreamRoutingDistribution] != 1 || seen[models.StreamHeartbeat] != 1 {
+		t.Errorf("Expected 3 processor stats, 1 distribution and a heartbeat, got %v", seen)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/routers/middleware/trace_id"
+	"voltarides/smart-router/telemetry"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestTelemetryUnknownExporter(t *testing.T) {
+	_, err := telemetry.Init(&config.TelemetryConfig{Exporter: "zipkin", ServiceName: "volta-router"})
+	if err == nil {
+		t.Error("Expected error for unknown exporter, got nil")
+	}
+}
+
+func TestTraceIDLinkedToOTLPSpan(t *testing.T) {
+	tracer, err := telemetry.Init(&config.TelemetryConfig{
+		Exporter:     "otlp",
+		ServiceName:  "volta-router",
+		Environment:  "test",
+		OTLPEndpoint: "127.0.0.1:1", // Nothing listens here; spans are dropped on shutdown
+		OTLPInsecure: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		tracer.Shutdown(ctx)
+		telemetry.Init(&config.TelemetryConfig{Exporter: "none"})
+	}()
+
+	e := echo.New()
+	e.Use(telemetry.Middleware())
+	e.Use(trace_id.TraceIDMiddleware())
+
+	var spanTraceID string
+	e.GET("/traced", func(c echo.Context) error {
+		spanTraceID = telemetry.SpanFromContext(c.Request().Context()).TraceID()
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/traced", nil))
+
+	if spanTraceID == "" {
+		t.Fatal("Expected an active span in the request context")
+	}
+	if header := rec.Header().Get("X-Trace-Id"); header != spanTraceID {
+		t.Errorf("Expected X-Trace-Id %s to match span trace ID, got %s", spanTraceID, header)
+	}
+}
+
+func TestTraceIDHeaderPreserved(t *testing.T) {
+	e := echo.New()
+	e.Use(telemetry.Middleware())
+	e.Use(trace_id.TraceIDMiddleware())
+	e.GET("/traced", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/traced", nil)
+	req.Header.Set("X-Trace-Id", "upstream-trace")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if header := rec.Header().Get("X-Trace-Id"); header != "upstream-trace" {
+		t.Errorf("Expected X-Trace-Id upstream-trace, got %s", header)
+	}
+}
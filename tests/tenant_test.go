@@ -0,0 +1,359 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"voltarides/smart-router/auth"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/grpcapi"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/proto/routerpb"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+	"voltarides/smart-router/webhooks"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// addTenant registers a tenant with its processors for the duration of the test
+func addTenant(t *testing.T, tenant string, processors map[string][]string) {
+	t.Helper()
+
+	config.ProcessorsByTenant[tenant] = processors
+	t.Cleanup(func() {
+		delete(config.ProcessorsByTenant, tenant)
+	})
+}
+
+// addTenantTransactions adds transactions of a tenant's processor, the first approved of them approved
+func addTenantTransactions(store *storage.InMemoryStore, tenant, processor, country string, approved, total int) {
+	transactions := make([]models.Transaction, 0, total)
+	for i := 0; i < total; i++ {
+		status := "declined"
+		if i < approved {
+			status = "approved"
+		}
+		transactions = append(transactions, models.Transaction{
+			ID:        tenant + "_" + processor + "_tx",
+			Tenant:    tenant,
+			Processor: processor,
+			Country:   country,
+			Status:    status,
+			Timestamp: time.Now().Add(-time.Minute),
+		})
+	}
+	store.AddTransactions(transactions)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+
+	store := storage.NewInMemoryStore()
+	rides := services.NewRoutingService(store, config.GetRoutingConfig())
+	food := rides.ForTenant("food")
+
+	// Both tenants contract RapidPay_BR, with different results
+	addTenantTransactions(store, "", "RapidPay_BR", "BR", 9, 10)
+	addTenantTransactions(store, "food", "RapidPay_BR", "BR", 5, 10)
+	addTenantTransactions(store, "food", "PayFlow_BR", "BR", 8, 10)
+
+	if rate := rides.CalculateApprovalRate("RapidPay_BR", "BR"); rate != 90.0 {
+		t.Errorf("Expected rides approval rate 90, got %.2f", rate)
+	}
+
+	// RapidPay_BR falls below the breaker threshold for food only
+	response, err := food.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Processor != "PayFlow_BR" {
+		t.Errorf("Expected food to route to PayFlow_BR, got %s", response.Processor)
+	}
+	if state := store.GetCircuitState("food", "RapidPay_BR", "BR", 5*time.Minute); state != models.CircuitOpen {
+		t.Errorf("Expected the food circuit to be open, got %s", state)
+	}
+	if state := store.GetCircuitState(config.DefaultTenant, "RapidPay_BR", "BR", 5*time.Minute); state != models.CircuitClosed {
+		t.Errorf("Expected the rides circuit to stay closed, got %s", state)
+	}
+
+	// Decisions, stats and processors are scoped by tenant
+	decision, err := food.GetRoutingDecision(response.DecisionID)
+	if err != nil || decision.Tenant != "food" {
+		t.Errorf("Expected the food decision, got %+v (%v)", decision, err)
+	}
+	if _, err := rides.GetRoutingDecision(response.DecisionID); err == nil {
+		t.Error("Expected another tenant's decision to be reported as not found")
+	}
+
+	stats, _ := rides.GetRoutingStats(models.RoutingStatsQuery{})
+	if stats.TotalDecisions != 0 || stats.Tenant != config.DefaultTenant {
+		t.Errorf("Expected no rides decisions, got %d for %s", stats.TotalDecisions, stats.Tenant)
+	}
+
+	if processors := food.GetAllProcessorStats(); len(processors) != 2 {
+		t.Errorf("Expected 2 food processors, got %d", len(processors))
+	}
+	if _, err := food.GetProcessorStats("TurboAcquire_BR"); err == nil {
+		t.Error("Expected a processor the tenant does not contract to be not found")
+	}
+	if _, err := food.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 100, Currency: "MXN", Country: "MX"}, false); err == nil {
+		t.Error("Expected a country the tenant does not route in to be unsupported")
+	}
+
+	// Outcomes are recorded for the reporting tenant
+	tx, err := food.RecordOutcome(models.TransactionOutcomeRequest{DecisionID: response.DecisionID, Country: "BR", Status: "approved"})
+	if err != nil || tx.Tenant != "food" {
+		t.Errorf("Expected a food transaction, got %+v (%v)", tx, err)
+	}
+	if _, err := rides.RecordOutcome(models.TransactionOutcomeRequest{DecisionID: response.DecisionID, Country: "BR", Status: "approved"}); err == nil {
+		t.Error("Expected an outcome linked to another tenant's decision to be rejected")
+	}
+}
+
+func TestTenantResolution(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+
+	authenticator, err := auth.NewAuthenticator(&config.SecurityConfig{
+		AuthEnabled: true,
+		APIKeys: []config.APIKeyConfig{
+			{Client: "checkout", Key: "router-key", Roles: []string{auth.RoleRouterClient}},
+			{Client: "food-checkout", Key: "food-key", Roles: []string{auth.RoleRouterClient}, Tenant: "food"},
+			{Client: "root", Key: "admin-key", Roles: []string{auth.RoleAdmin}},
+		},
+		JWTSecret: string(jwtSecret),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	e := newAuthRouter(storage.NewInMemoryStore(), authenticator, newDisabledLimiter())
+
+	token, _ := auth.SignJWT(jwtSecret, "", "food-dashboard", "food", []string{auth.RoleOperator}, time.Minute)
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		token      string
+		tenant     string
+		status     int
+		processors int
+	}{
+		{"default tenant", "router-key", "", "", http.StatusOK, 9},
+		{"client tenant", "food-key", "", "", http.StatusOK, 2},
+		{"token tenant", "", token, "", http.StatusOK, 2},
+		{"own tenant requested", "food-key", "", "food", http.StatusOK, 2},
+		{"other tenant requested", "food-key", "", config.DefaultTenant, http.StatusForbidden, 0},
+		{"admin acts for a tenant", "admin-key", "", "food", http.StatusOK, 2},
+		{"unknown tenant", "admin-key", "", "scooters", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/volta-router/v1/processors", nil)
+		if tt.apiKey != "" {
+			req.Header.Set("X-API-Key", tt.apiKey)
+		}
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		if tt.tenant != "" {
+			req.Header.Set("X-Tenant", tt.tenant)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d (%s)", tt.name, tt.status, rec.Code, rec.Body.String())
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+
+		var response models.ProcessorHealthResponse
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if len(response.Processors) != tt.processors {
+			t.Errorf("%s: expected %d processors, got %d", tt.name, tt.processors, len(response.Processors))
+		}
+	}
+
+	if _, err := auth.NewAuthenticator(&config.SecurityConfig{AuthEnabled: true, APIKeys: []config.APIKeyConfig{
+		{Client: "scooters", Key: "k", Roles: []string{auth.RoleRouterClient}, Tenant: "scooters"},
+	}}); err == nil {
+		t.Error("Expected an API key of an unknown tenant to be rejected")
+	}
+}
+
+func TestGRPCTenant(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+
+	authenticator, _ := auth.NewAuthenticator(&config.SecurityConfig{
+		AuthEnabled: true,
+		APIKeys: []config.APIKeyConfig{
+			{Client: "food-checkout", Key: "food-key", Roles: []string{auth.RoleRouterClient}, Tenant: "food"},
+		},
+	})
+	service := services.NewRoutingService(storage.NewInMemoryStore(), config.GetRoutingConfig())
+
+	client := serveTestRouter(t, service, grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcapi.UnaryAuthInterceptor(authenticator)),
+		grpc.ChainStreamInterceptor(grpcapi.StreamAuthInterceptor(authenticator)),
+	))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "food-key")
+
+	response, err := client.GetProcessorStats(ctx, &routerpb.GetProcessorStatsRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.GetProcessors()) != 2 {
+		t.Errorf("Expected 2 food processors, got %d", len(response.GetProcessors()))
+	}
+
+	if _, err := client.GetProcessorStats(ctx, &routerpb.GetProcessorStatsRequest{Country: "MX"}); err == nil {
+		t.Error("Expected a country the tenant does not route in to be rejected")
+	}
+}
+
+func TestLoadTenantProcessors(t *testing.T) {
+	t.Cleanup(func() {
+		delete(config.ProcessorsByTenant, "scooters")
+	})
+
+	t.Setenv("TENANT_PROCESSORS", `{"scooters":{"MX":["RapidPay_MX"]}}`)
+	if err := config.LoadTenantProcessors(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.IsTenant("scooters") || !config.IsTenant(config.DefaultTenant) {
+		t.Errorf("Expected the loaded and default tenants, got %v", config.Tenants())
+	}
+
+	invalid := []string{
+		`not json`,
+		`{"scooters":{}}`,
+		`{"scooters":{"MX":[]}}`,
+	}
+	for _, value := range invalid {
+		t.Setenv("TENANT_PROCESSORS", value)
+		if err := config.LoadTenantProcessors(); err == nil {
+			t.Errorf("Expected %s to be rejected", value)
+		}
+	}
+}
+
+func TestTenantRoutingConfig(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+
+	// Food tolerates lower approval rates before opening a circuit
+	foodConfig := *config.GetRoutingConfig()
+	foodConfig.CircuitBreakerThreshold = 40.0
+
+	store := storage.NewInMemoryStore()
+	rides := services.NewRoutingService(store, config.GetRoutingConfig(), services.WithTenantConfigs(map[string]*config.RoutingConfig{"food": &foodConfig}))
+	food := rides.ForTenant("food")
+
+	addTenantTransactions(store, "", "RapidPay_BR", "BR", 5, 10)
+	addTenantTransactions(store, "", "TurboAcquire_BR", "BR", 9, 10)
+	addTenantTransactions(store, "food", "RapidPay_BR", "BR", 5, 10)
+	addTenantTransactions(store, "food", "PayFlow_BR", "BR", 9, 10)
+
+	req := models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}
+	for _, service := range []*services.RoutingService{rides, food} {
+		if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", service.Tenant(), err)
+		}
+	}
+
+	if state := store.GetCircuitState(config.DefaultTenant, "RapidPay_BR", "BR", 5*time.Minute); state != models.CircuitOpen {
+		t.Errorf("Expected the rides circuit to open below 60%%, got %s", state)
+	}
+	if state := store.GetCircuitState("food", "RapidPay_BR", "BR", 5*time.Minute); state != models.CircuitClosed {
+		t.Errorf("Expected the food circuit to stay closed above 40%%, got %s", state)
+	}
+
+	// Switching back to the default tenant restores the default configuration
+	response, err := food.ForTenant(config.DefaultTenant).WhatIf(context.Background(), models.RoutingConfigOverride{}, []models.RoutingRequest{req})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if response.Config.CircuitBreakerThreshold != 60.0 {
+		t.Errorf("Expected the default threshold 60, got %.0f", response.Config.CircuitBreakerThreshold)
+	}
+}
+
+func TestLoadTenantRoutingConfig(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+	base := config.GetRoutingConfig()
+
+	t.Setenv("TENANT_ROUTING_CONFIG", `{"food":{"time_window":"30m","circuit_breaker_threshold":40}}`)
+	configs, err := config.LoadTenantRoutingConfig(base)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	food := configs["food"]
+	if food == nil || food.TimeWindow != 30*time.Minute || food.CircuitBreakerThreshold != 40 || food.HighRiskThreshold != base.HighRiskThreshold {
+		t.Errorf("Expected food settings over the defaults, got %+v", food)
+	}
+	if _, exists := configs[config.DefaultTenant]; exists {
+		t.Error("Expected no configuration for a tenant that is not listed")
+	}
+
+	invalid := []string{
+		`not json`,
+		`{"scooters":{"time_window":"30m"}}`,
+		`{"food":{"time_window":"soon"}}`,
+		`{"food":{"circuit_breaker_timeout":"-5m"}}`,
+		`{"food":{"circuit_breaker_threshold":120}}`,
+	}
+	for _, value := range invalid {
+		t.Setenv("TENANT_ROUTING_CONFIG", value)
+		if _, err := config.LoadTenantRoutingConfig(base); err == nil {
+			t.Errorf("Expected %s to be rejected", value)
+		}
+	}
+}
+
+func TestTenantWebhooks(t *testing.T) {
+	addTenant(t, "food", map[string][]string{"BR": {"RapidPay_BR", "PayFlow_BR"}})
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher()
+	defer dispatcher.Close()
+	bus := events.NewBus()
+	bus.Subscribe(dispatcher.Handle)
+
+	ridesSubscription, _ := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	foodSubscription, _ := dispatcher.Subscribe("food", models.WebhookSubscriptionRequest{URL: receiver.URL})
+
+	// Only food's RapidPay_BR circuit opens
+	store := storage.NewInMemoryStore()
+	food := services.NewRoutingService(store, config.GetRoutingConfig(), services.WithEventBus(bus)).ForTenant("food")
+	addTenantTransactions(store, "food", "RapidPay_BR", "BR", 5, 10)
+	addTenantTransactions(store, "food", "PayFlow_BR", "BR", 9, 10)
+	if _, err := food.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 100, Currency: "BRL", Country: "BR"}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	delivery := waitForDelivery(t, dispatcher, "food", foodSubscription.ID)
+	if delivery.Tenant != "food" || delivery.EventType != events.CircuitOpened {
+		t.Errorf("Expected a food circuit.opened delivery, got %+v", delivery)
+	}
+	if deliveries := dispatcher.Deliveries(config.DefaultTenant, ridesSubscription.ID, ""); len(deliveries) != 0 {
+		t.Errorf("Expected no deliveries of food events to rides, got %d", len(deliveries))
+	}
+
+	// Subscriptions are managed per tenant
+	if subscriptions := dispatcher.Subscriptions("food"); len(subscriptions) != 1 || subscriptions[0].ID != foodSubscription.ID {
+		t.Errorf("Expected only the food subscription, got %+v", subscriptions)
+	}
+	if dispatcher.Unsubscribe(config.DefaultTenant, foodSubscription.ID) {
+		t.Error("Expected another tenant's subscription to be reported as not found")
+	}
+}
@@ -0,0 +1,360 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+	"voltarides/smart-router/webhooks"
+)
+
+// waitForDelivery polls a tenant's delivery log until the subscription has a delivery in a final state
+func waitForDelivery(t *testing.T, dispatcher *webhooks.Dispatcher, tenant, subscriptionID string) models.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, delivery := range dispatcher.Deliveries(tenant, subscriptionID, "") {
+			if delivery.Status == models.DeliveryDelivered || delivery.Status == models.DeliveryDeadLettered {
+				return delivery
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Expected a finished delivery for subscription %s, got %+v", subscriptionID, dispatcher.Deliveries(tenant, subscriptionID, ""))
+	return models.WebhookDelivery{}
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		verified bool
+		received events.Event
+		header   http.Header
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhooks.HeaderTimestamp), 10, 64)
+
+		mu.Lock()
+		defer mu.Unlock()
+		verified = webhooks.Verify("s3cret", timestamp, body, r.Header.Get(webhooks.HeaderSignature))
+		header = r.Header.Clone()
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher()
+	defer dispatcher.Close()
+
+	subscription, err := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	event := events.New(events.CircuitOpened, time.Now(), models.CircuitEvent{Processor: "PayFlow_BR", Country: "BR", ToState: models.CircuitOpen})
+	dispatcher.Handle(event)
+
+	delivery := waitForDelivery(t, dispatcher, config.DefaultTenant, subscription.ID)
+	if delivery.Status != models.DeliveryDelivered || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusNoContent {
+		t.Errorf("Expected delivery on the first attempt with status 204, got %+v", delivery)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !verified {
+		t.Error("Expected the payload signature to verify with the subscription secret")
+	}
+	if received.ID != event.ID || received.Type != events.CircuitOpened {
+		t.Errorf("Expected event %s of type circuit.opened, got %s of type %s", event.ID, received.ID, received.Type)
+	}
+	if header.Get(webhooks.HeaderEvent) != events.CircuitOpened || header.Get(webhooks.HeaderDelivery) != delivery.ID {
+		t.Errorf("Expected event and delivery headers, got %s and %s", header.Get(webhooks.HeaderEvent), header.Get(webhooks.HeaderDelivery))
+	}
+}
+
+func TestWebhookRetriesWithBackoff(t *testing.T) {
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher(webhooks.WithRetryPolicy(5, time.Millisecond, 5*time.Millisecond))
+	defer dispatcher.Close()
+
+	subscription, _ := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	if subscription.Secret == "" {
+		t.Error("Expected a secret to be generated")
+	}
+
+	dispatcher.Handle(events.New(events.CircuitClosed, time.Now(), nil))
+
+	delivery := waitForDelivery(t, dispatcher, config.DefaultTenant, subscription.ID)
+	if delivery.Status != models.DeliveryDelivered {
+		t.Fatalf("Expected delivered after retries, got %s (%s)", delivery.Status, delivery.Error)
+	}
+	if delivery.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", delivery.Attempts)
+	}
+}
+
+func TestWebhookDeadLetterAndRedeliver(t *testing.T) {
+	var healthy atomic.Bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher(webhooks.WithRetryPolicy(3, time.Millisecond, time.Millisecond))
+	defer dispatcher.Close()
+
+	subscription, _ := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	dispatcher.Handle(events.New(events.CountryUnavailable, time.Now(), nil))
+
+	delivery := waitForDelivery(t, dispatcher, config.DefaultTenant, subscription.ID)
+	if delivery.Status != models.DeliveryDeadLettered || delivery.Attempts != 3 {
+		t.Fatalf("Expected dead letter after 3 attempts, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+	if delivery.ResponseStatus != http.StatusInternalServerError || delivery.Error == "" {
+		t.Errorf("Expected last status 500 with an error, got %d (%s)", delivery.ResponseStatus, delivery.Error)
+	}
+
+	deadLetters := dispatcher.DeadLetters(config.DefaultTenant)
+	if len(deadLetters) != 1 || deadLetters[0].ID != delivery.ID {
+		t.Fatalf("Expected the delivery in the dead-letter list, got %+v", deadLetters)
+	}
+
+	// Once the receiver recovers the dead letter can be redelivered
+	healthy.Store(true)
+	if _, err := dispatcher.Redeliver(config.DefaultTenant, delivery.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	delivery = waitForDelivery(t, dispatcher, config.DefaultTenant, subscription.ID)
+	if delivery.Status != models.DeliveryDelivered || delivery.Attempts != 1 {
+		t.Errorf("Expected redelivery on the first attempt, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+	if len(dispatcher.DeadLetters(config.DefaultTenant)) != 0 {
+		t.Errorf("Expected dead-letter list to be empty, got %d", len(dispatcher.DeadLetters(config.DefaultTenant)))
+	}
+
+	if _, err := dispatcher.Redeliver(config.DefaultTenant, delivery.ID); err == nil {
+		t.Error("Expected error when redelivering a delivery that is not dead-lettered")
+	}
+}
+
+func TestWebhookDeliveriesBeyondTheLogCap(t *testing.T) {
+	// More deliveries than the delivery log keeps are in flight at once
+	const total = 1100
+	release := make(chan struct{})
+	var received sync.Map
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		received.Store(r.Header.Get(webhooks.HeaderDelivery), true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher(webhooks.WithWorkers(4, total))
+	defer dispatcher.Close()
+
+	subscription, _ := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	for i := 0; i < total; i++ {
+		dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+	}
+
+	if pending := dispatcher.Deliveries(config.DefaultTenant, subscription.ID, models.DeliveryPending); len(pending) != total {
+		t.Fatalf("Expected %d pending deliveries, got %d", total, len(pending))
+	}
+	close(release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(dispatcher.Deliveries(config.DefaultTenant, subscription.ID, models.DeliveryPending)) > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	count := 0
+	received.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	if count != total {
+		t.Errorf("Expected all %d deliveries to reach the receiver, got %d", total, count)
+	}
+	if delivered := dispatcher.Deliveries(config.DefaultTenant, subscription.ID, models.DeliveryDelivered); len(delivered) != 1000 {
+		t.Errorf("Expected the delivery log to keep the last 1000 delivered, got %d", len(delivered))
+	}
+}
+
+func TestWebhookQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	// One delivery is attempted, one waits in the queue and the third does not fit
+	dispatcher := webhooks.NewDispatcher(webhooks.WithWorkers(1, 1))
+	defer dispatcher.Close()
+
+	dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+	time.Sleep(50 * time.Millisecond)
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+
+	deadLetters := dispatcher.DeadLetters(config.DefaultTenant)
+	if len(deadLetters) != 1 || deadLetters[0].Error != "delivery queue is full" {
+		t.Errorf("Expected 1 dead letter for the full queue, got %+v", deadLetters)
+	}
+}
+
+func TestWebhookDeadLetterLimit(t *testing.T) {
+	release := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	// With the worker and the queue busy, every further delivery is dead-lettered
+	dispatcher := webhooks.NewDispatcher(webhooks.WithWorkers(1, 1), webhooks.WithDeadLetterLimit(2))
+	defer dispatcher.Close()
+
+	labels := map[string]string{"tenant": config.DefaultTenant}
+	before := counterValue(t, "volta_router_webhook_dead_letters_dropped_total", labels)
+
+	dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL})
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+	time.Sleep(50 * time.Millisecond)
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+
+	var last string
+	for i := 0; i < 3; i++ {
+		dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+		deadLetters := dispatcher.DeadLetters(config.DefaultTenant)
+		last = deadLetters[0].ID
+	}
+
+	deadLetters := dispatcher.DeadLetters(config.DefaultTenant)
+	if len(deadLetters) != 2 || deadLetters[0].ID != last {
+		t.Errorf("Expected the 2 newest dead letters to be kept, got %+v", deadLetters)
+	}
+	if dropped := counterValue(t, "volta_router_webhook_dead_letters_dropped_total", labels) - before; dropped != 1 {
+		t.Errorf("Expected 1 dropped dead letter to be counted, got %.0f", dropped)
+	}
+}
+
+func TestWebhookEventTypeFilter(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	dispatcher := webhooks.NewDispatcher()
+	defer dispatcher.Close()
+
+	if _, err := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL, EventTypes: []string{"circuit.flapped"}}); err == nil {
+		t.Error("Expected error for an unknown event type")
+	}
+
+	subscription, _ := dispatcher.Subscribe(config.DefaultTenant, models.WebhookSubscriptionRequest{URL: receiver.URL, EventTypes: []string{events.CircuitOpened}})
+	dispatcher.Handle(events.New(events.CountryHighRisk, time.Now(), nil))
+
+	if deliveries := dispatcher.Deliveries(config.DefaultTenant, subscription.ID, ""); len(deliveries) != 0 {
+		t.Errorf("Expected no deliveries for an unsubscribed event type, got %d", len(deliveries))
+	}
+
+	if subscriptions := dispatcher.Subscriptions(config.DefaultTenant); len(subscriptions) != 1 || subscriptions[0].Secret != "" {
+		t.Errorf("Expected 1 subscription listed without its secret, got %+v", subscriptions)
+	}
+
+	if !dispatcher.Unsubscribe(config.DefaultTenant, subscription.ID) || dispatcher.Unsubscribe(config.DefaultTenant, subscription.ID) {
+		t.Error("Expected unsubscribe to succeed once")
+	}
+}
+
+func TestRoutingServicePublishesEvents(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	bus := events.NewBus()
+	service := services.NewRoutingService(store, config.GetRoutingConfig(), services.WithEventBus(bus))
+
+	var published []events.Event
+	bus.Subscribe(func(event events.Event) {
+		published = append(published, event)
+	})
+
+	// Best rate 65% is high risk, PayFlow_BR at 50% trips its circuit
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 13, 20, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 12, 20, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "PayFlow_BR", "BR", 10, 20, now.Add(-5*time.Minute))
+
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+	for i := 0; i < 2; i++ {
+		if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, event := range published {
+		counts[event.Type]++
+	}
+	if counts[events.CircuitOpened] != 1 {
+		t.Errorf("Expected 1 circuit.opened event, got %d", counts[events.CircuitOpened])
+	}
+	if counts[events.CountryHighRisk] != 1 {
+		t.Errorf("Expected 1 country.high_risk event across repeated routing, got %d", counts[events.CountryHighRisk])
+	}
+
+	// Recovery above the threshold is announced once
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 60, 60, now.Add(-time.Minute))
+	if _, err := service.SelectBestProcessor(context.Background(), req, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	last := published[len(published)-1]
+	change, ok := last.Data.(models.CountryStatusChange)
+	if last.Type != events.CountryRecovered || !ok || change.FromStatus != models.CountryStatusHighRisk {
+		t.Errorf("Expected country.recovered from high_risk, got %s with %+v", last.Type, last.Data)
+	}
+
+	// Simulated routing does not publish
+	before := len(published)
+	if _, err := service.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 10, Currency: "MXN", Country: "MX"}, true); err == nil {
+		t.Fatal("Expected error for a country without processor data")
+	}
+	if len(published) != before {
+		t.Errorf("Expected simulated routing not to publish events, got %d new", len(published)-before)
+	}
+
+	if _, err := service.SelectBestProcessor(context.Background(), models.RoutingRequest{Amount: 10, Currency: "MXN", Country: "MX"}, false); err == nil {
+		t.Fatal("Expected error for a country without processor data")
+	}
+	if published[len(published)-1].Type != events.CountryUnavailable {
+		t.Errorf("Expected country.no_processors event, got %s", published[len(published)-1].Type)
+	}
+}
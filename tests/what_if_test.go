@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/models"
+	"voltarides/smart-router/services"
+	"voltarides/smart-router/storage"
+)
+
+func TestWhatIfHigherCircuitThreshold(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	cfg := config.GetRoutingConfig()
+	service := services.NewRoutingService(store, cfg)
+
+	now := time.Now()
+	addProcessorTransactions(store, "RapidPay_BR", "BR", 9, 10, now.Add(-5*time.Minute))
+	addProcessorTransactions(store, "TurboAcquire_BR", "BR", 13, 20, now.Add(-5*time.Minute)) // 65%
+
+	threshold := 70.0
+	override := models.RoutingConfigOverride{CircuitBreakerThreshold: &threshold, TimeWindow: "30m"}
+	req := models.RoutingRequest{Amount: 100.0, Currency: "BRL", Country: "BR"}
+
+	response, err := service.WhatIf(context.Background(), override, []models.RoutingRequest{req, req})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Config.CircuitBreakerThreshold != 70.0 || response.Config.TimeWindow != "30m0s" {
+		t.Errorf("Expected effective config with 70%% threshold and 30m window, got %+v", response.Config)
+	}
+
+	if len(response.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(response.Results))
+	}
+
+	first := response.Results[0]
+	if first.Decision == nil || first.Decision.Processor != "RapidPay_BR" {
+		t.Fatalf("Expected RapidPay_BR decision, got %+v", first.Decision)
+	}
+	for _, candidate := range first.Rankings {
+		if candidate.Processor == "TurboAcquire_BR" {
+			t.Error("Expected TurboAcquire_BR to be excluded from rankings at a 70% threshold")
+		}
+	}
+	if len(first.CircuitTransitions) != 1 || first.CircuitTransitions[0].Processor != "TurboAcquire_BR" {
+		t.Errorf("Expected TurboAcquire_BR circuit to open, got %+v", first.CircuitTransitions)
+	}
+
+	// The second request sees the hypothetical open circuit and triggers no new transition
+	if len(response.Results[1].CircuitTransitions) != 0 {
+		t.Errorf("Expected no new transitions for the second request, got %d", len(response.Results[1].CircuitTransitions))
+	}
+
+	// Production state and configuration are untouched
+	if state := store.GetCircuitState(config.DefaultTenant, "TurboAcquire_BR", "BR", cfg.CircuitBreakerTimeout); state != models.CircuitClosed {
+		t.Errorf("Expected TurboAcquire_BR circuit to stay closed, got %s", state)
+	}
+	if cfg.CircuitBreakerThreshold != 60.0 {
+		t.Errorf("Expected production threshold to stay at 60, got %.1f", cfg.CircuitBreakerThreshold)
+	}
+	if store.CountRoutingDecisions(config.DefaultTenant) != 0 {
+		t.Errorf("Expected no routing decisions recorded, got %d", store.CountRoutingDecisions(config.DefaultTenant))
+	}
+}
+
+func TestWhatIfPerRequestErrors(t *testing.T) {
+	store := storage.NewInMemoryStore()
+	service := services.NewRoutingService(store, config.GetRoutingConfig())
+
+	addProcessorTransactions(store, "RapidPay_MX", "MX", 9, 10, time.Now().Add(-5*time.Minute))
+
+	reqs := []models.RoutingRequest{
+		{Amount: 100.0, Currency: "USD", Country: "US"},
+		{Amount: 100.0, Currency: "MXN", Country: "MX"},
+	}
+
+	response, err := service.WhatIf(context.Background(), models.RoutingConfigOverride{}, reqs)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if response.Results[0].Error == nil {
+		t.Error("Expected an error result for an unsupported country")
+	}
+	if response.Results[1].Decision == nil || response.Results[1].Decision.Processor != "RapidPay_MX" {
+		t.Errorf("Expected RapidPay_MX decision, got %+v", response.Results[1].Decision)
+	}
+}
+
+func TestWhatIfInvalidOverride(t *testing.T) {
+	service := services.NewRoutingService(storage.NewInMemoryStore(), config.GetRoutingConfig())
+
+	invalid := 120.0
+	overrides := []models.RoutingConfigOverride{
+		{TimeWindow: "soon"},
+		{CircuitBreakerTimeout: "-5m"},
+		{CircuitBreakerThreshold: &invalid},
+	}
+
+	for _, override := range overrides {
+		if _, err := service.WhatIf(context.Background(), override, []models.RoutingRequest{{Amount: 1, Currency: "BRL", Country: "BR"}}); err == nil {
+			t.Errorf("Expected error for override %+v", override)
+		}
+	}
+}
@@ -0,0 +1,552 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"voltarides/smart-router/config"
+	"voltarides/smart-router/events"
+	"voltarides/smart-router/metrics"
+	"voltarides/smart-router/models"
+
+	"github.com/google/uuid"
+)
+
+// maxDeliveryLog caps the delivered deliveries kept in the delivery log
+// Pending and retrying deliveries are kept until they finish, dead letters until they are redelivered or dropped
+const maxDeliveryLog = 1000
+
+// defaultMaxDeadLetters caps the dead letters kept per tenant; the oldest are dropped beyond it
+const defaultMaxDeadLetters = 1000
+
+// Dispatcher delivers router events to subscribed URLs as HMAC-signed JSON
+//
+// Deliveries are queued and attempted by a fixed pool of workers, and retried with exponential backoff
+// on network errors and non-2xx responses. Deliveries that exhaust their attempts, or do not fit in the
+// queue, are moved to the dead-letter list, from where they can be redelivered
+type Dispatcher struct {
+	client      *http.Client
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	workers     int
+	queue       chan *models.WebhookDelivery
+	maxDead     int
+
+	subscriptions map[string]*models.WebhookSubscription
+	pending       map[string]*models.WebhookDelivery // queued, in-flight and retrying deliveries, key: delivery ID
+	retries       map[string]*time.Timer             // scheduled retries, key: delivery ID
+	deliveries    []*models.WebhookDelivery          // delivered deliveries, oldest first
+	deadLetters   map[string]*models.WebhookDelivery // key: delivery ID
+	payloads      map[string][]byte                  // bodies of undelivered deliveries, key: delivery ID
+	closed        bool
+	mu            sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// DispatcherOption configures a Dispatcher
+type DispatcherOption func(*Dispatcher)
+
+// WithHTTPClient sets the client used for deliveries
+func WithHTTPClient(client *http.Client) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithRetryPolicy sets the attempts per delivery and the backoff between them
+// The wait before attempt n+1 is base * 2^(n-1), capped at max
+func WithRetryPolicy(maxAttempts int, base, max time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.baseBackoff = base
+		d.maxBackoff = max
+	}
+}
+
+// WithWorkers sets the number of concurrent delivery attempts and how many deliveries can wait for a worker
+func WithWorkers(workers, queueSize int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = workers
+		d.queue = make(chan *models.WebhookDelivery, queueSize)
+	}
+}
+
+// WithDeadLetterLimit sets the number of dead letters kept per tenant
+func WithDeadLetterLimit(limit int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxDead = limit
+	}
+}
+
+// NewDispatcher creates a dispatcher with 5 attempts per delivery, backing off from 1s up to 1m,
+// 8 workers behind a queue of 10000 deliveries, and up to 1000 dead letters per tenant
+func NewDispatcher(opts ...DispatcherOption) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher := &Dispatcher{
+		client:        &http.Client{Timeout: 5 * time.Second},
+		maxAttempts:   5,
+		baseBackoff:   time.Second,
+		maxBackoff:    time.Minute,
+		workers:       8,
+		queue:         make(chan *models.WebhookDelivery, 10000),
+		maxDead:       defaultMaxDeadLetters,
+		subscriptions: make(map[string]*models.WebhookSubscription),
+		pending:       make(map[string]*models.WebhookDelivery),
+		retries:       make(map[string]*time.Timer),
+		deliveries:    make([]*models.WebhookDelivery, 0),
+		deadLetters:   make(map[string]*models.WebhookDelivery),
+		payloads:      make(map[string][]byte),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+
+	for _, opt := range opts {
+		opt(dispatcher)
+	}
+
+	for i := 0; i < dispatcher.workers; i++ {
+		dispatcher.wg.Add(1)
+		go dispatcher.work()
+	}
+
+	return dispatcher
+}
+
+// Close stops retries, waits for in-flight attempts to finish and dead-letters every undelivered delivery
+func (d *Dispatcher) Close() {
+	stopped := errors.New("dispatcher stopped before the delivery succeeded")
+
+	d.mu.Lock()
+	d.closed = true
+	for id, timer := range d.retries {
+		timer.Stop()
+		delete(d.retries, id)
+		d.finish(d.pending[id], models.DeliveryDeadLettered, 0, stopped)
+	}
+	d.mu.Unlock()
+
+	d.cancel()
+	d.wg.Wait()
+
+	// Deliveries still queued were never picked up by a worker
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for len(d.queue) > 0 {
+		d.finish(<-d.queue, models.DeliveryDeadLettered, 0, stopped)
+	}
+}
+
+// Subscribe registers a URL for the requested event types of a tenant and returns the subscription with its secret
+func (d *Dispatcher) Subscribe(tenant string, req models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	for _, eventType := range req.EventTypes {
+		if !events.IsValidType(eventType) {
+			return nil, fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	eventTypes := req.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	subscription := &models.WebhookSubscription{
+		ID:         uuid.New().String(),
+		Tenant:     config.TenantOrDefault(tenant),
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		CreatedAt:  time.Now(),
+	}
+
+	d.mu.Lock()
+	d.subscriptions[subscription.ID] = subscription
+	d.mu.Unlock()
+
+	created := *subscription
+	return &created, nil
+}
+
+// Unsubscribe removes a tenant's subscription, reporting whether it existed
+func (d *Dispatcher) Unsubscribe(tenant, id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if subscription, exists := d.subscriptions[id]; !exists || subscription.Tenant != tenant {
+		return false
+	}
+	delete(d.subscriptions, id)
+	return true
+}
+
+// Subscriptions returns a tenant's subscriptions, oldest first, without their secrets
+func (d *Dispatcher) Subscriptions(tenant string) []models.WebhookSubscription {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	subscriptions := make([]models.WebhookSubscription, 0, len(d.subscriptions))
+	for _, subscription := range d.subscriptions {
+		if subscription.Tenant != tenant {
+			continue
+		}
+		listed := *subscription
+		listed.Secret = ""
+		subscriptions = append(subscriptions, listed)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+
+	return subscriptions
+}
+
+// Handle starts a delivery of event to every matching subscription of the event's tenant; it is meant to be
+// subscribed to an events.Bus
+func (d *Dispatcher) Handle(event events.Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	tenant := config.TenantOrDefault(event.Tenant)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, subscription := range d.subscriptions {
+		if subscription.Tenant != tenant || !subscribedTo(subscription, event.Type) {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			ID:             uuid.New().String(),
+			Tenant:         tenant,
+			SubscriptionID: subscription.ID,
+			URL:            subscription.URL,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Status:         models.DeliveryPending,
+			CreatedAt:      time.Now(),
+		}
+		d.pending[delivery.ID] = delivery
+		d.payloads[delivery.ID] = body
+		d.enqueue(delivery)
+	}
+}
+
+// Deliveries returns a tenant's pending, dead-lettered and recently delivered deliveries, newest first,
+// optionally filtered by subscription and status
+func (d *Dispatcher) Deliveries(tenant, subscriptionID, status string) []models.WebhookDelivery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	add := func(delivery *models.WebhookDelivery) {
+		if delivery.Tenant != tenant {
+			return
+		}
+		if subscriptionID != "" && delivery.SubscriptionID != subscriptionID {
+			return
+		}
+		if status != "" && delivery.Status != status {
+			return
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	for _, delivery := range d.pending {
+		add(delivery)
+	}
+	for _, delivery := range d.deadLetters {
+		add(delivery)
+	}
+	for _, delivery := range d.deliveries {
+		add(delivery)
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+
+	return deliveries
+}
+
+// DeadLetters returns a tenant's deliveries that exhausted their attempts, newest first
+func (d *Dispatcher) DeadLetters(tenant string) []models.WebhookDelivery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	deadLetters := make([]models.WebhookDelivery, 0, len(d.deadLetters))
+	for _, delivery := range d.deadLetters {
+		if delivery.Tenant != tenant {
+			continue
+		}
+		deadLetters = append(deadLetters, *delivery)
+	}
+	sort.Slice(deadLetters, func(i, j int) bool {
+		return deadLetters[i].CreatedAt.After(deadLetters[j].CreatedAt)
+	})
+
+	return deadLetters
+}
+
+// Redeliver retries a tenant's dead-lettered delivery with a fresh set of attempts
+func (d *Dispatcher) Redeliver(tenant, id string) (*models.WebhookDelivery, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil, errors.New("dispatcher is stopped")
+	}
+	delivery, exists := d.deadLetters[id]
+	if !exists || delivery.Tenant != tenant {
+		return nil, fmt.Errorf("dead letter %s not found", id)
+	}
+	if _, exists := d.subscriptions[delivery.SubscriptionID]; !exists {
+		return nil, fmt.Errorf("subscription %s no longer exists", delivery.SubscriptionID)
+	}
+
+	delete(d.deadLetters, id)
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.Error = ""
+	delivery.NextAttemptAt = nil
+	d.pending[id] = delivery
+
+	redelivered := *delivery
+	d.enqueue(delivery)
+	return &redelivered, nil
+}
+
+// work attempts queued deliveries until the dispatcher is closed
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case delivery := <-d.queue:
+			d.attempt(delivery)
+		}
+	}
+}
+
+// attempt makes one delivery attempt, then finishes the delivery or schedules its next attempt
+func (d *Dispatcher) attempt(delivery *models.WebhookDelivery) {
+	d.mu.RLock()
+	subscription, subscribed := d.subscriptions[delivery.SubscriptionID]
+	body := d.payloads[delivery.ID]
+	var secret string
+	if subscribed {
+		secret = subscription.Secret
+	}
+	d.mu.RUnlock()
+
+	if !subscribed {
+		d.mu.Lock()
+		d.finish(delivery, models.DeliveryDeadLettered, 0, errors.New("subscription was removed"))
+		d.mu.Unlock()
+		return
+	}
+
+	status, err := d.post(delivery, secret, body)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = status
+
+	if err == nil {
+		d.finish(delivery, models.DeliveryDelivered, status, nil)
+		return
+	}
+	if d.closed || d.ctx.Err() != nil {
+		d.finish(delivery, models.DeliveryDeadLettered, status, errors.New("dispatcher stopped before the delivery succeeded"))
+		return
+	}
+	if delivery.Attempts >= d.maxAttempts {
+		d.finish(delivery, models.DeliveryDeadLettered, status, err)
+		return
+	}
+
+	wait := d.backoff(delivery.Attempts)
+	next := now.Add(wait)
+	delivery.Status = models.DeliveryRetrying
+	delivery.Error = err.Error()
+	delivery.NextAttemptAt = &next
+	d.retries[delivery.ID] = time.AfterFunc(wait, func() {
+		d.retry(delivery)
+	})
+}
+
+// retry queues a delivery whose backoff has elapsed, unless Close cancelled the retry first
+func (d *Dispatcher) retry(delivery *models.WebhookDelivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, scheduled := d.retries[delivery.ID]; !scheduled {
+		return
+	}
+	delete(d.retries, delivery.ID)
+	d.enqueue(delivery)
+}
+
+// enqueue hands a pending delivery to the workers, dead-lettering it when the queue is full
+// Callers must hold the write lock
+func (d *Dispatcher) enqueue(delivery *models.WebhookDelivery) {
+	if d.closed {
+		d.finish(delivery, models.DeliveryDeadLettered, 0, errors.New("dispatcher stopped before the delivery succeeded"))
+		return
+	}
+
+	select {
+	case d.queue <- delivery:
+	default:
+		d.finish(delivery, models.DeliveryDeadLettered, 0, errors.New("delivery queue is full"))
+	}
+}
+
+// post sends one attempt and returns the response status
+func (d *Dispatcher) post(delivery *models.WebhookDelivery, secret string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// finish records the final state of a pending delivery, moving it to the delivery log or the dead letters
+// Callers must hold the write lock
+func (d *Dispatcher) finish(delivery *models.WebhookDelivery, status string, responseStatus int, err error) {
+	if delivery == nil {
+		return
+	}
+
+	delete(d.pending, delivery.ID)
+	delivery.Status = status
+	delivery.NextAttemptAt = nil
+	if responseStatus != 0 {
+		delivery.ResponseStatus = responseStatus
+	}
+
+	if status == models.DeliveryDelivered {
+		now := time.Now()
+		delivery.DeliveredAt = &now
+		delivery.Error = ""
+		delete(d.payloads, delivery.ID)
+		d.appendDelivery(delivery)
+		return
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	d.deadLetters[delivery.ID] = delivery
+	d.dropDeadLetters(delivery.Tenant)
+}
+
+// dropDeadLetters drops a tenant's oldest dead letters, and their payloads, beyond the cap
+// Callers must hold the write lock
+func (d *Dispatcher) dropDeadLetters(tenant string) {
+	deadLetters := make([]*models.WebhookDelivery, 0)
+	for _, delivery := range d.deadLetters {
+		if delivery.Tenant == tenant {
+			deadLetters = append(deadLetters, delivery)
+		}
+	}
+	if len(deadLetters) <= d.maxDead {
+		return
+	}
+
+	sort.Slice(deadLetters, func(i, j int) bool {
+		return deadLetters[i].CreatedAt.Before(deadLetters[j].CreatedAt)
+	})
+	for _, delivery := range deadLetters[:len(deadLetters)-d.maxDead] {
+		delete(d.deadLetters, delivery.ID)
+		delete(d.payloads, delivery.ID)
+		metrics.RecordWebhookDeadLetterDropped(tenant)
+	}
+}
+
+// backoff returns the wait after the given number of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= d.maxBackoff {
+			return d.maxBackoff
+		}
+	}
+	return wait
+}
+
+// appendDelivery adds a delivered delivery to the log, dropping the oldest entries beyond the cap
+// Callers must hold the write lock
+func (d *Dispatcher) appendDelivery(delivery *models.WebhookDelivery) {
+	d.deliveries = append(d.deliveries, delivery)
+	if len(d.deliveries) > maxDeliveryLog {
+		d.deliveries = d.deliveries[len(d.deliveries)-maxDeliveryLog:]
+	}
+}
+
+// subscribedTo reports whether a subscription wants events of the given type
+func subscribedTo(subscription *models.WebhookSubscription, eventType string) bool {
+	if len(subscription.EventTypes) == 0 {
+		return true
+	}
+	for _, subscribed := range subscription.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// generateSecret returns a random 32-byte secret, hex encoded
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderSignature = "X-Volta-Signature"
+	HeaderTimestamp = "X-Volta-Timestamp"
+	HeaderEvent     = "X-Volta-Event"
+	HeaderDelivery  = "X-Volta-Delivery"
+)
+
+// Sign returns the signature header value for a payload sent at timestamp (Unix seconds)
+// The signed message is "<timestamp>.<body>", so a captured payload cannot be replayed with a new timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for the payload, for use by receivers
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}